@@ -30,6 +30,8 @@ import (
 	"time"
 )
 
+// httprouter does not allow static path segments next to a wildcard (e.g. /processes/import next to /processes/:id);
+// static endpoints for processes are therefore registered below /v2/processes
 var endpoints = []func(config config.Config, control Controller, router *httprouter.Router){}
 
 func Start(ctx context.Context, config config.Config, control Controller) {
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
+	"github.com/SENERGY-Platform/process-model-repository/lib/config"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func init() {
+	endpoints = append(endpoints, ArchiveEndpoints)
+}
+
+const maxArchiveSize = 512 << 20
+const archiveTimeout = 10 * time.Minute
+
+func ArchiveEndpoints(config config.Config, control Controller, router *httprouter.Router) {
+	resource := "/v2/processes/archive"
+
+	//query parameters:
+	//	search
+	//	ids			comma seperated list of process-model ids
+	//response:
+	//	zip archive with <id>.bpmn, <id>.svg and <id>.json (model.ArchiveMetadata) per readable process
+	router.GET(resource, func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		listOptions := model.ListOptions{Search: request.URL.Query().Get("search")}
+		if request.URL.Query().Has("ids") {
+			listOptions.Ids = []string{}
+			if idsParam := request.URL.Query().Get("ids"); idsParam != "" {
+				listOptions.Ids = strings.Split(strings.TrimSpace(idsParam), ",")
+			}
+		}
+		extendDeadlines(writer, archiveTimeout)
+		out := &headerOnWriteResponse{ResponseWriter: writer, header: map[string]string{
+			"Content-Type":        "application/zip",
+			"Content-Disposition": `attachment; filename="processes.zip"`,
+		}}
+		err, code := control.ExportProcesses(token, listOptions, out)
+		if err != nil && !out.written {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		if err != nil {
+			log.Println("ERROR: unable to finish archive export", err)
+		}
+	})
+
+	//request body:
+	//	zip archive; every .bpmn file is imported, optionally with .svg and .json (model.ArchiveMetadata) files of the same base name
+	//response:
+	//	[]model.ArchiveImportResult with one entry per .bpmn file
+	router.POST(resource, func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		extendDeadlines(writer, archiveTimeout)
+		archive, err := io.ReadAll(http.MaxBytesReader(writer, request.Body, maxArchiveSize))
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := control.ImportProcesses(token, archive)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			log.Println("ERROR: unable to encode response", err)
+		}
+	})
+}
+
+// extendDeadlines overwrites the server read and write timeouts for long-running archive requests
+func extendDeadlines(writer http.ResponseWriter, timeout time.Duration) {
+	controller := http.NewResponseController(writer)
+	deadline := time.Now().Add(timeout)
+	if err := controller.SetReadDeadline(deadline); err != nil {
+		log.Println("WARNING: unable to extend read deadline", err)
+	}
+	if err := controller.SetWriteDeadline(deadline); err != nil {
+		log.Println("WARNING: unable to extend write deadline", err)
+	}
+}
+
+// headerOnWriteResponse sets header only if something is written,
+// so that errors before the first write may still be responded with http.Error
+type headerOnWriteResponse struct {
+	http.ResponseWriter
+	header  map[string]string
+	written bool
+}
+
+func (this *headerOnWriteResponse) Write(b []byte) (int, error) {
+	if !this.written {
+		this.written = true
+		for key, value := range this.header {
+			this.ResponseWriter.Header().Set(key, value)
+		}
+	}
+	return this.ResponseWriter.Write(b)
+}
+
+func (this *headerOnWriteResponse) Unwrap() http.ResponseWriter {
+	return this.ResponseWriter
+}
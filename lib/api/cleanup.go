@@ -0,0 +1,54 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
+	"github.com/SENERGY-Platform/process-model-repository/lib/config"
+	"github.com/julienschmidt/httprouter"
+	"log"
+	"net/http"
+)
+
+func init() {
+	endpoints = append(endpoints, CleanupEndpoints)
+}
+
+func CleanupEndpoints(config config.Config, control Controller, router *httprouter.Router) {
+	//requires the admin role; returns the report of the last reconciliation of processes and permissions of this instance
+	//	responds with 404 if no cleanup ran since the start of the instance
+	//response:
+	//	model.CleanupReport	with the ids of the orphaned processes and permissions
+	router.GET("/admin/cleanup/report", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, errCode := control.GetLastCleanupReport(token)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			log.Println("ERROR: unable to encode response", err)
+		}
+	})
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"log"
+	"net/http"
+)
+
+type ValidationErrorResponse struct {
+	Error    string                    `json:"error"`
+	Findings []model.ValidationFinding `json:"findings"`
+}
+
+// writeError responds with a ValidationErrorResponse json body if err is a *model.ValidationError
+// and falls back to http.Error otherwise
+func writeError(writer http.ResponseWriter, err error, code int) {
+	var validationErr *model.ValidationError
+	if !errors.As(err, &validationErr) {
+		http.Error(writer, err.Error(), code)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+	writer.WriteHeader(code)
+	err = json.NewEncoder(writer).Encode(ValidationErrorResponse{Error: validationErr.Error(), Findings: validationErr.Findings})
+	if err != nil {
+		log.Println("ERROR: unable to encode response", err)
+	}
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"errors"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func revisionETag(revision int64) string {
+	return `"` + strconv.FormatInt(revision, 10) + `"`
+}
+
+// getIfMatchRevision returns the revision referenced by the If-Match header
+// or model.AnyRevision if the header is missing or '*'
+func getIfMatchRevision(request *http.Request) (revision int64, err error) {
+	ifMatch := strings.TrimSpace(request.Header.Get("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return model.AnyRevision, nil
+	}
+	ifMatch = strings.TrimPrefix(ifMatch, "W/")
+	if !strings.HasPrefix(ifMatch, `"`) || !strings.HasSuffix(ifMatch, `"`) || len(ifMatch) < 2 {
+		return model.AnyRevision, errors.New("invalid If-Match header, expect a single etag")
+	}
+	revision, err = strconv.ParseInt(strings.Trim(ifMatch, `"`), 10, 64)
+	if err != nil || revision < 0 {
+		return model.AnyRevision, errors.New("invalid If-Match header, unknown etag")
+	}
+	return revision, nil
+}
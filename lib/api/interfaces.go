@@ -19,14 +19,43 @@ package api
 import (
 	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
 	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"io"
 )
 
 type Controller interface {
 	ReadProcess(token auth.Token, id string, action model.AuthAction) (result model.Process, err error, errCode int)
 	ListProcesses(token auth.Token, options model.ListOptions) ([]model.Process, int64, error, int)
-	ReadAllPublicProcess() ([]model.Process, error, int)
+	ListPublicProcesses(options model.ListOptions) ([]model.Process, int64, error, int)
+	ListPublicProcessCategories(options model.ListOptions) ([]model.ValueCount, error, int)
 	CreateProcess(token auth.Token, process model.Process) (model.Process, error, int)
-	UpdateProcess(token auth.Token, id string, process model.Process) (model.Process, error, int)
+	UpdateProcess(token auth.Token, id string, process model.Process, expectedRevision int64) (model.Process, error, int)
 	UpdateProcessPublic(token auth.Token, id string, public model.PublicCommand) (model.Process, error, int)
 	DeleteProcess(token auth.Token, id string) (error, int)
+	ValidateProcess(process model.Process) (model.ValidationResult, error, int)
+	ReadProcessSvg(token auth.Token, id string) (string, error, int)
+	ExportProcesses(token auth.Token, options model.ListOptions, out io.Writer) (error, int)
+	ImportProcesses(token auth.Token, archive []byte) ([]model.ArchiveImportResult, error, int)
+	CopyProcess(token auth.Token, id string) (model.Process, error, int)
+	ListProcessTags(token auth.Token, options model.ListOptions) ([]model.ValueCount, error, int)
+	ListProcessFolders(token auth.Token, options model.ListOptions) ([]model.ValueCount, error, int)
+	BulkMoveProcesses(token auth.Token, command model.BulkMoveCommand) ([]model.BulkResult, error, int)
+	BulkTagProcesses(token auth.Token, command model.BulkTagCommand) ([]model.BulkResult, error, int)
+
+	ReadProcessPermissions(token auth.Token, id string) (model.ProcessPermissions, error, int)
+	SetProcessPermissions(token auth.Token, id string, permissions model.ProcessPermissions) (model.ProcessPermissions, error, int)
+	ListProcessAuditRecords(token auth.Token, id string, limit int64, offset int64) ([]model.AuditRecord, int64, error, int)
+	TransferProcessOwner(token auth.Token, id string, command model.OwnerTransferCommand) (model.Process, error, int)
+	RestoreProcess(token auth.Token, id string) (model.Process, error, int)
+	GetLastCleanupReport(token auth.Token) (model.CleanupReport, error, int)
+
+	ReadTemplate(token auth.Token, id string) (model.ProcessTemplate, error, int)
+	ListTemplates(token auth.Token, options model.TemplateListOptions) ([]model.ProcessTemplate, int64, error, int)
+	CreateTemplate(token auth.Token, template model.ProcessTemplate) (model.ProcessTemplate, error, int)
+	UpdateTemplate(token auth.Token, id string, template model.ProcessTemplate) (model.ProcessTemplate, error, int)
+	DeleteTemplate(token auth.Token, id string) (error, int)
+	InstantiateTemplate(token auth.Token, id string, instantiation model.TemplateInstantiation) (model.Process, error, int)
+
+	ListProcessRevisions(token auth.Token, id string, limit int64, offset int64) ([]model.ProcessRevision, int64, error, int)
+	ReadProcessRevision(token auth.Token, id string, revision int64) (model.ProcessRevision, error, int)
+	RestoreProcessRevision(token auth.Token, id string, revision int64) (model.Process, error, int)
 }
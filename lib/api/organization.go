@@ -0,0 +1,148 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
+	"github.com/SENERGY-Platform/process-model-repository/lib/config"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"github.com/julienschmidt/httprouter"
+	"log"
+	"net/http"
+)
+
+func init() {
+	endpoints = append(endpoints, OrganizationEndpoints)
+}
+
+func OrganizationEndpoints(config config.Config, control Controller, router *httprouter.Router) {
+	resource := "/v2/processes"
+
+	//query parameters:
+	//	p			r|w|x|a default r
+	//	search, ids, category, tag, folder, topic, message, signal, lane, input_parameter, output_parameter, timer_type, min_task_count, max_task_count
+	//				like GET /v2/processes; only matching processes are counted
+	//response:
+	//	[]model.ValueCount	tags of the accessible processes with the count of processes per tag, sorted by tag
+	router.GET(resource+"/tags", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		listOptions, err := parseProcessListOptions(request.URL.Query(), 0, model.ProcessFieldsFull)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, errCode := control.ListProcessTags(token, listOptions)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			log.Println("ERROR: unable to encode response", err)
+		}
+	})
+
+	//query parameters:
+	//	like GET /v2/processes/tags
+	//response:
+	//	[]model.ValueCount	folders of the accessible processes with the count of processes directly in the folder, sorted by folder;
+	//						sub folders are not included in the count of their parent; the root folder is omitted
+	router.GET(resource+"/folders", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		listOptions, err := parseProcessListOptions(request.URL.Query(), 0, model.ProcessFieldsFull)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, errCode := control.ListProcessFolders(token, listOptions)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			log.Println("ERROR: unable to encode response", err)
+		}
+	})
+
+	//request body:
+	//	model.BulkMoveCommand; requires write permission for every process
+	//response:
+	//	[]model.BulkResult	with one entry per id
+	router.POST(resource+"/bulk/move", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		command := model.BulkMoveCommand{}
+		err = json.NewDecoder(request.Body).Decode(&command)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, errCode := control.BulkMoveProcesses(token, command)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			log.Println("ERROR: unable to encode response", err)
+		}
+	})
+
+	//request body:
+	//	model.BulkTagCommand; requires write permission for every process
+	//response:
+	//	[]model.BulkResult	with one entry per id
+	router.POST(resource+"/bulk/tags", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		command := model.BulkTagCommand{}
+		err = json.NewDecoder(request.Body).Decode(&command)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, errCode := control.BulkTagProcesses(token, command)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			log.Println("ERROR: unable to encode response", err)
+		}
+	})
+}
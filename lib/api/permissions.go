@@ -0,0 +1,167 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package api
+
+import (
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
+	"github.com/SENERGY-Platform/process-model-repository/lib/config"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"github.com/julienschmidt/httprouter"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+func init() {
+	endpoints = append(endpoints, PermissionsEndpoints)
+}
+
+func PermissionsEndpoints(config config.Config, control Controller, router *httprouter.Router) {
+	resource := "/processes/:id"
+
+	//requires the administrate right
+	//response:
+	//	model.ProcessPermissions
+	router.GET(resource+"/permissions", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, errCode := control.ReadProcessPermissions(token, id)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			log.Println("ERROR: unable to encode response", err)
+		}
+	})
+
+	//requires the administrate right; replaces all user, group and role rights
+	//	role rights are kept if role_permissions is missing or null
+	//	responds with 400 if no user, group or role keeps the administrate right
+	//	responds with 403 if role rights are changed by a user without the admin role
+	//	responds with 403 if group rights are granted or changed by a user who is not a member of the group
+	//	responds with 409 if the process is in the trash
+	//request body:
+	//	model.ProcessPermissions
+	//response:
+	//	model.ProcessPermissions	as stored; entries without rights are removed
+	router.PUT(resource+"/permissions", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		permissions := model.ProcessPermissions{}
+		err = json.NewDecoder(request.Body).Decode(&permissions)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, errCode := control.SetProcessPermissions(token, id, permissions)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			log.Println("ERROR: unable to encode response", err)
+		}
+	})
+
+	//requires the admin role; grants the new owner all rights and records the transfer in the audit
+	//request body:
+	//	model.OwnerTransferCommand
+	//response:
+	//	model.Process	with the new owner
+	router.POST(resource+"/owner", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		command := model.OwnerTransferCommand{}
+		err = json.NewDecoder(request.Body).Decode(&command)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, errCode := control.TransferProcessOwner(token, id, command)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			log.Println("ERROR: unable to encode response", err)
+		}
+	})
+
+	//requires the administrate right
+	//query parameters:
+	//	limit		default 100
+	//	offset
+	//response:
+	//	[]model.AuditRecord	in body, newest first
+	//	total in X-Total-Count response header
+	router.GET(resource+"/audit", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var limit int64 = 100
+		limitParam := request.URL.Query().Get("limit")
+		if limitParam != "" {
+			limit, err = strconv.ParseInt(limitParam, 10, 64)
+		}
+		if err != nil {
+			http.Error(writer, "unable to parse limit:"+err.Error(), http.StatusBadRequest)
+			return
+		}
+		var offset int64 = 0
+		offsetParam := request.URL.Query().Get("offset")
+		if offsetParam != "" {
+			offset, err = strconv.ParseInt(offsetParam, 10, 64)
+		}
+		if err != nil {
+			http.Error(writer, "unable to parse offset:"+err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, total, err, errCode := control.ListProcessAuditRecords(token, id, limit, offset)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			log.Println("ERROR: unable to encode response", err)
+		}
+	})
+}
@@ -18,12 +18,17 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
 	"github.com/SENERGY-Platform/process-model-repository/lib/config"
 	"github.com/SENERGY-Platform/process-model-repository/lib/model"
 	"github.com/julienschmidt/httprouter"
+	"io"
 	"log"
+	"mime"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -32,6 +37,8 @@ func init() {
 	endpoints = append(endpoints, ProcessEndpoints)
 }
 
+const maxBpmnSize = 64 << 20
+
 func ProcessEndpoints(config config.Config, control Controller, router *httprouter.Router) {
 	resource := "/processes"
 
@@ -54,6 +61,7 @@ func ProcessEndpoints(config config.Config, control Controller, router *httprout
 			http.Error(writer, err.Error(), errCode)
 			return
 		}
+		writer.Header().Set("ETag", revisionETag(result.Revision))
 		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
 		err = json.NewEncoder(writer).Encode(result)
 		if err != nil {
@@ -62,71 +70,167 @@ func ProcessEndpoints(config config.Config, control Controller, router *httprout
 		return
 	})
 
+	//responds with the svg preview of the process as image/svg+xml
+	//	the svg is rendered from the bpmn diagram interchange if the process has no stored svg
+	//	the svg may be uploaded by users: scripts and external resources are blocked by the Content-Security-Policy
+	router.GET(resource+"/:id/svg", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, errCode := control.ReadProcessSvg(token, id)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+		writer.Header().Set("X-Content-Type-Options", "nosniff")
+		writer.Header().Set("Content-Type", "image/svg+xml; charset=utf-8")
+		_, err = writer.Write([]byte(result))
+		if err != nil {
+			log.Println("ERROR: unable to write response", err)
+		}
+	})
+
+	//responds with the bare bpmn xml of the process as file download
+	router.GET(resource+"/:id/bpmn", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, errCode := control.ReadProcess(token, id, model.READ)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("ETag", revisionETag(result.Revision))
+		writer.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		writer.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": bpmnFileName(result)}))
+		_, err = writer.Write([]byte(result.BpmnXml))
+		if err != nil {
+			log.Println("ERROR: unable to write response", err)
+		}
+	})
+
 	//query parameters:
 	//	limit		default 100
 	//	offset
-	//	search
-	//	sort		name.asc
+	//	continuation_token	X-Continuation-Token of the previous page; replaces offset; requires the same sort
+	//	total		true|false default true; false skips counting the total
+	//	fields		summary|full|comma seperated list of process fields; default summary (all fields except bpmn_xml and svgXML)
+	//	search		full-text search over name, description and bpmn element names/documentation; every word has to match
+	//	sort		name.asc; score.desc sorts search results by relevance
 	//	ids			comma seperated list of process-model ids
 	//	p			r|w|x|a default r
+	//	category			processes with the category
+	//	tag					processes with the tag
+	//	folder				processes in the folder or its sub folders (slash separated path)
+	//	removed				true lists removed processes (in the trash or archived on user delete) instead of active processes
+	//	topic				processes using the external task topic
+	//	message				processes declaring the message name
+	//	signal				processes declaring the signal name
+	//	lane				processes containing the lane name
+	//	input_parameter		processes using the camunda input parameter name
+	//	output_parameter	processes using the camunda output parameter name
+	//	timer_type			processes containing a timer of the type timeDate|timeDuration|timeCycle
+	//	min_task_count
+	//	max_task_count
 	//response:
-	//	[]model.Process	in body
-	//	total in X-Total-Count response header
+	//	[]model.Process	in body; with highlights of the matched words if search is set
+	//	total in X-Total-Count response header, if total != false
+	//	token for the next page in X-Continuation-Token response header, if the page is full
 	router.GET("/v2"+resource, func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 		token, err := auth.GetParsedToken(request)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-
-		listOptions := model.ListOptions{
-			Limit:  100,
-			Offset: 0,
-		}
-		limitParam := request.URL.Query().Get("limit")
-		if limitParam != "" {
-			listOptions.Limit, err = strconv.ParseInt(limitParam, 10, 64)
+		listOptions, err := parseProcessListOptions(request.URL.Query(), 100, model.ProcessFieldsSummary)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
 		}
+		result, total, err, errCode := control.ListProcesses(token, listOptions)
 		if err != nil {
-			http.Error(writer, "unable to parse limit:"+err.Error(), http.StatusBadRequest)
+			http.Error(writer, err.Error(), errCode)
 			return
 		}
+		writeProcessList(writer, listOptions, result, total)
+	})
 
-		offsetParam := request.URL.Query().Get("offset")
-		if offsetParam != "" {
-			listOptions.Offset, err = strconv.ParseInt(offsetParam, 10, 64)
+	//public catalog of published processes; no authentication needed
+	//query parameters:
+	//	limit		default 100; clients that need more have to page with offset or continuation_token
+	//	offset
+	//	continuation_token	X-Continuation-Token of the previous page; replaces offset; requires the same sort
+	//	total		true|false default true; false skips counting the total
+	//	fields		summary|full|comma seperated list of process fields; default summary, fields=full to receive bpmn_xml and svgXML
+	//	search		full-text search over name, description and bpmn element names/documentation; every word has to match
+	//	sort		name.asc; score.desc sorts search results by relevance
+	//	category	published processes with the category
+	//	tag, folder, topic, message, signal, lane, input_parameter, output_parameter, timer_type, min_task_count, max_task_count
+	//				like GET /v2/processes
+	//response:
+	//	[]model.Process	of published processes; with highlights of the matched words if search is set
+	//	total in X-Total-Count response header, if total != false
+	//	token for the next page in X-Continuation-Token response header, if the page is full
+	router.GET(resource, func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		listOptions, err := parseProcessListOptions(request.URL.Query(), 100, model.ProcessFieldsSummary)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
 		}
+		result, total, err, errCode := control.ListPublicProcesses(listOptions)
 		if err != nil {
-			http.Error(writer, "unable to parse offset:"+err.Error(), http.StatusBadRequest)
+			http.Error(writer, err.Error(), errCode)
 			return
 		}
+		writeProcessList(writer, listOptions, result, total)
+	})
 
-		idsParam := request.URL.Query().Get("ids")
-		if request.URL.Query().Has("ids") {
-			if idsParam != "" {
-				listOptions.Ids = strings.Split(strings.TrimSpace(idsParam), ",")
-			} else {
-				listOptions.Ids = []string{}
-			}
+	//categories of published processes with the count of processes per category, sorted by category
+	//	accepts the search and filter query parameters of GET /processes to count only matching processes
+	//response:
+	//	[]model.ValueCount
+	router.GET("/v2"+resource+"/categories", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		listOptions, err := parseProcessListOptions(request.URL.Query(), 0, model.ProcessFieldsFull)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
 		}
-
-		listOptions.Search = request.URL.Query().Get("search")
-		listOptions.SortBy = request.URL.Query().Get("sort")
-		if listOptions.SortBy == "" {
-			listOptions.SortBy = "name.asc"
+		result, err, errCode := control.ListPublicProcessCategories(listOptions)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
 		}
-
-		listOptions.Permission = model.AuthAction(request.URL.Query().Get("p"))
-		if listOptions.Permission == "" {
-			listOptions.Permission = model.READ
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			log.Println("ERROR: unable to encode response", err)
 		}
+	})
 
-		result, total, err, errCode := control.ListProcesses(token, listOptions)
+	router.POST(resource, func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		process := model.Process{}
+		err := json.NewDecoder(request.Body).Decode(&process)
 		if err != nil {
-			http.Error(writer, err.Error(), errCode)
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := control.CreateProcess(token, process)
+		if err != nil {
+			writeError(writer, err, code)
 			return
 		}
-		writer.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
 		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
 		err = json.NewEncoder(writer).Encode(result)
 		if err != nil {
@@ -135,10 +239,24 @@ func ProcessEndpoints(config config.Config, control Controller, router *httprout
 		return
 	})
 
-	router.GET(resource, func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-		result, err, errCode := control.ReadAllPublicProcess()
+	//creates a process from a raw bpmn file in the request body (e.g. exported by the camunda modeler)
+	//	the name is extracted from the bpmn; the svg is rendered from the bpmn diagram interchange
+	//response:
+	//	model.Process in body
+	router.POST("/v2"+resource+"/import", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
 		if err != nil {
-			http.Error(writer, err.Error(), errCode)
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		bpmn, err := io.ReadAll(http.MaxBytesReader(writer, request.Body, maxBpmnSize))
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := control.CreateProcess(token, model.Process{BpmnXml: string(bpmn)})
+		if err != nil {
+			writeError(writer, err, code)
 			return
 		}
 		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -146,34 +264,60 @@ func ProcessEndpoints(config config.Config, control Controller, router *httprout
 		if err != nil {
 			log.Println("ERROR: unable to encode response", err)
 		}
-		return
 	})
 
-	router.POST(resource, func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	//dry-run of the name extraction and validation used by POST and PUT
+	//	lists all findings in model.ValidationResult; responds with 200 even if the process is invalid
+	//	requires a valid token like the other write endpoints
+	router.POST("/v2"+resource+"/validate", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		_, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
 		process := model.Process{}
-		err := json.NewDecoder(request.Body).Decode(&process)
+		err = json.NewDecoder(request.Body).Decode(&process)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
+		result, err, code := control.ValidateProcess(process)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			log.Println("ERROR: unable to encode response", err)
+		}
+	})
+
+	//creates a copy of a readable or published process, owned by the caller
+	//the bpmn:process and bpmn:collaboration ids of the copy are replaced
+	//response: the created model.Process
+	router.POST(resource+"/:id/copy", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
 		token, err := auth.GetParsedToken(request)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		result, err, code := control.CreateProcess(token, process)
+		result, err, code := control.CopyProcess(token, id)
 		if err != nil {
 			http.Error(writer, err.Error(), code)
 			return
 		}
+		writer.Header().Set("ETag", revisionETag(result.Revision))
 		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
 		err = json.NewEncoder(writer).Encode(result)
 		if err != nil {
 			log.Println("ERROR: unable to encode response", err)
 		}
-		return
 	})
 
+	//optional If-Match header with the ETag of GET /processes/:id
+	//		responds with 412 if the process has been changed since
 	router.PUT(resource+"/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 		process := model.Process{}
 		id := params.ByName("id")
@@ -187,11 +331,17 @@ func ProcessEndpoints(config config.Config, control Controller, router *httprout
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		result, err, code := control.UpdateProcess(token, id, process)
+		expectedRevision, err := getIfMatchRevision(request)
 		if err != nil {
-			http.Error(writer, err.Error(), code)
+			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
+		result, err, code := control.UpdateProcess(token, id, process, expectedRevision)
+		if err != nil {
+			writeError(writer, err, code)
+			return
+		}
+		writer.Header().Set("ETag", revisionETag(result.Revision))
 		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
 		err = json.NewEncoder(writer).Encode(result)
 		if err != nil {
@@ -224,6 +374,8 @@ func ProcessEndpoints(config config.Config, control Controller, router *httprout
 		}
 	})
 
+	//requires the administrate right; moves the process into the trash (see GET /v2/processes/trash)
+	//	processes in the trash, and all processes if trash_retention_days is 0, are deleted immediately
 	router.DELETE(resource+"/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 		id := params.ByName("id")
 		token, err := auth.GetParsedToken(request)
@@ -239,3 +391,166 @@ func ProcessEndpoints(config config.Config, control Controller, router *httprout
 		writer.WriteHeader(http.StatusOK)
 	})
 }
+
+var fileNameReplacer = regexp.MustCompile(`[^\p{L}\p{N}._ -]+`)
+
+func bpmnFileName(process model.Process) string {
+	name := strings.TrimSpace(fileNameReplacer.ReplaceAllString(process.Name, "_"))
+	if name == "" {
+		name = process.Id
+	}
+	return name + ".bpmn"
+}
+
+// projectProcesses reduces the processes to the requested fields; nil fields keep the complete processes
+func projectProcesses(processes []model.Process, fields []string) (result interface{}, err error) {
+	if fields == nil {
+		return processes, nil
+	}
+	var projected []map[string]interface{}
+	for _, process := range processes {
+		projection, err := model.ProjectProcess(process, fields)
+		if err != nil {
+			return result, err
+		}
+		projected = append(projected, projection)
+	}
+	return projected, nil
+}
+
+// parseProcessListOptions reads the query parameters of the process list endpoints
+func parseProcessListOptions(query url.Values, defaultLimit int64, defaultFields string) (listOptions model.ListOptions, err error) {
+	listOptions = model.ListOptions{
+		Limit:  defaultLimit,
+		Offset: 0,
+	}
+	limitParam := query.Get("limit")
+	if limitParam != "" {
+		listOptions.Limit, err = strconv.ParseInt(limitParam, 10, 64)
+		if err != nil {
+			return listOptions, errors.New("unable to parse limit:" + err.Error())
+		}
+	}
+
+	offsetParam := query.Get("offset")
+	if offsetParam != "" {
+		listOptions.Offset, err = strconv.ParseInt(offsetParam, 10, 64)
+		if err != nil {
+			return listOptions, errors.New("unable to parse offset:" + err.Error())
+		}
+	}
+
+	idsParam := query.Get("ids")
+	if query.Has("ids") {
+		if idsParam != "" {
+			listOptions.Ids = strings.Split(strings.TrimSpace(idsParam), ",")
+		} else {
+			listOptions.Ids = []string{}
+		}
+	}
+
+	listOptions.Search = query.Get("search")
+	listOptions.SortBy = query.Get("sort")
+	if listOptions.SortBy == "" {
+		listOptions.SortBy = "name.asc"
+	}
+
+	continuationToken := query.Get("continuation_token")
+	if continuationToken != "" {
+		after, err := model.ParseListCursor(continuationToken)
+		if err != nil {
+			return listOptions, err
+		}
+		listOptions.After = &after
+	}
+
+	fieldsParam := query.Get("fields")
+	if fieldsParam == "" {
+		fieldsParam = defaultFields
+	}
+	listOptions.Fields, err = model.ParseProcessFields(fieldsParam)
+	if err != nil {
+		return listOptions, err
+	}
+
+	totalParam := query.Get("total")
+	if totalParam != "" {
+		withTotal, err := strconv.ParseBool(totalParam)
+		if err != nil {
+			return listOptions, errors.New("unable to parse total:" + err.Error())
+		}
+		listOptions.WithoutTotal = !withTotal
+	}
+
+	listOptions.Permission = model.AuthAction(query.Get("p"))
+	if listOptions.Permission == "" {
+		listOptions.Permission = model.READ
+	}
+
+	removedParam := query.Get("removed")
+	if removedParam != "" {
+		listOptions.Removed, err = strconv.ParseBool(removedParam)
+		if err != nil {
+			return listOptions, errors.New("unable to parse removed:" + err.Error())
+		}
+	}
+
+	listOptions.Category = strings.TrimSpace(query.Get("category"))
+	listOptions.Tag = strings.TrimSpace(query.Get("tag"))
+	listOptions.Folder, err = model.NormalizeFolder(query.Get("folder"))
+	if err != nil {
+		return listOptions, err
+	}
+	listOptions.Topic = query.Get("topic")
+	listOptions.Message = query.Get("message")
+	listOptions.Signal = query.Get("signal")
+	listOptions.Lane = query.Get("lane")
+	listOptions.InputParameter = query.Get("input_parameter")
+	listOptions.OutputParameter = query.Get("output_parameter")
+	listOptions.TimerType = query.Get("timer_type")
+	minTaskCountParam := query.Get("min_task_count")
+	if minTaskCountParam != "" {
+		listOptions.MinTaskCount, err = strconv.ParseInt(minTaskCountParam, 10, 64)
+		if err != nil {
+			return listOptions, errors.New("unable to parse min_task_count:" + err.Error())
+		}
+	}
+	maxTaskCountParam := query.Get("max_task_count")
+	if maxTaskCountParam != "" {
+		listOptions.MaxTaskCount, err = strconv.ParseInt(maxTaskCountParam, 10, 64)
+		if err != nil {
+			return listOptions, errors.New("unable to parse max_task_count:" + err.Error())
+		}
+	}
+	return listOptions, nil
+}
+
+// writeProcessList responds with the processes projected to listOptions.Fields
+// and sets the X-Total-Count and X-Continuation-Token headers
+func writeProcessList(writer http.ResponseWriter, listOptions model.ListOptions, result []model.Process, total int64) {
+	if !listOptions.WithoutTotal {
+		writer.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+	}
+	if listOptions.Ids == nil && !strings.HasPrefix(listOptions.SortBy, model.SearchScoreSort+".") && listOptions.Limit > 0 && int64(len(result)) == listOptions.Limit {
+		next, err := model.NewListCursor(listOptions.SortBy, result[len(result)-1])
+		continuationToken := ""
+		if err == nil {
+			continuationToken, err = next.Encode()
+		}
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("X-Continuation-Token", continuationToken)
+	}
+	response, err := projectProcesses(result, listOptions.Fields)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+	err = json.NewEncoder(writer).Encode(response)
+	if err != nil {
+		log.Println("ERROR: unable to encode response", err)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
+	"github.com/SENERGY-Platform/process-model-repository/lib/config"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"github.com/julienschmidt/httprouter"
+	"log"
+	"net/http"
+)
+
+func init() {
+	endpoints = append(endpoints, RemovalEndpoints)
+}
+
+func RemovalEndpoints(config config.Config, control Controller, router *httprouter.Router) {
+	resource := "/processes"
+
+	//lists the processes in the trash and the processes archived on user delete
+	//query parameters:
+	//	p			r|w|x|a default a
+	//	other parameters like GET /v2/processes
+	//response:
+	//	[]model.Process	with removal information; like GET /v2/processes
+	router.GET("/v2"+resource+"/trash", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		listOptions, err := parseProcessListOptions(request.URL.Query(), 100, model.ProcessFieldsSummary)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if request.URL.Query().Get("p") == "" {
+			listOptions.Permission = model.ADMINISTRATE
+		}
+		listOptions.Removed = true
+		result, total, err, errCode := control.ListProcesses(token, listOptions)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writeProcessList(writer, listOptions, result, total)
+	})
+
+	//requires the administrate right; takes a process out of the trash or the user delete archive
+	//	responds with 400 if the process is not removed
+	//response:
+	//	model.Process
+	router.POST(resource+"/:id/restore", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, errCode := control.RestoreProcess(token, id)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			log.Println("ERROR: unable to encode response", err)
+		}
+	})
+}
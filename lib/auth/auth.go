@@ -33,6 +33,7 @@ type RealmAccess = map[string][]string
 
 type KeycloakClaims struct {
 	RealmAccess RealmAccess `json:"realm_access"`
+	Groups      []string    `json:"groups,omitempty"`
 	gojwt.StandardClaims
 }
 
@@ -41,9 +42,14 @@ func CreateToken(issuer string, userId string) (token Token, err error) {
 }
 
 func CreateTokenWithRoles(issuer string, userId string, roles []string) (token Token, err error) {
+	return CreateTokenWithGroups(issuer, userId, roles, nil)
+}
+
+func CreateTokenWithGroups(issuer string, userId string, roles []string, groups []string) (token Token, err error) {
 	realmAccess := RealmAccess{"roles": roles}
 	claims := KeycloakClaims{
 		realmAccess,
+		groups,
 		gojwt.StandardClaims{
 			ExpiresAt: time.Now().Add(10 * time.Minute).Unix(),
 			Issuer:    issuer,
@@ -60,5 +66,6 @@ func CreateTokenWithRoles(issuer string, userId string, roles []string) (token T
 	token.Token = "Bearer " + tokenString
 	token.Sub = userId
 	token.RealmAccess = realmAccess
+	token.Groups = groups
 	return token, nil
 }
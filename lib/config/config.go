@@ -28,21 +28,47 @@ import (
 )
 
 type Config struct {
-	LogLevel               string `json:"log_level"` //DEBUG | CALL | NONE
-	ServerPort             string `json:"server_port"`
-	GroupId                string `json:"group_id"`
-	ProcessTopic           string `json:"process_topic"`
-	UsersTopic             string `json:"users_topic"`
-	PermissionsV2Url       string `json:"permissions_v2_url"`
-	MongoUrl               string `json:"mongo_url"`
-	MongoReplSet           bool   `json:"mongo_repl_set"` //set true if mongodb is configured as replication set or mongos and is able to handle transactions
-	MongoTable             string `json:"mongo_table"`
-	MongoProcessCollection string `json:"mongo_process_collection"`
-	Debug                  bool   `json:"debug"`
-	ConnectivityTest       bool   `json:"connectivity_test"`
-	KafkaUrl               string `json:"kafka_url"`
-	RunStartupMigration    bool   `json:"run_startup_migration"`
-	CleanupInterval        string `json:"cleanup_interval"`
+	LogLevel                string `json:"log_level"` //DEBUG | CALL | NONE
+	ServerPort              string `json:"server_port"`
+	GroupId                 string `json:"group_id"`
+	ProcessTopic            string `json:"process_topic"`
+	UsersTopic              string `json:"users_topic"`
+	PermissionsV2Url        string `json:"permissions_v2_url"`
+	DatabaseType            string `json:"database_type"` //mongo | postgres | memory; memory is not persistent and intended for tests and local development
+	PostgresUrl             string `json:"postgres_url"`
+	MongoUrl                string `json:"mongo_url"`
+	MongoReplSet            bool   `json:"mongo_repl_set"` //set true if mongodb is configured as replication set or mongos and is able to handle transactions
+	MongoTable              string `json:"mongo_table"`
+	MongoProcessCollection  string `json:"mongo_process_collection"`
+	MongoRevisionCollection string `json:"mongo_revision_collection"`
+	MongoTemplateCollection string `json:"mongo_template_collection"`
+	MongoOutboxCollection   string `json:"mongo_outbox_collection"`
+	MongoAuditCollection    string `json:"mongo_audit_collection"`
+	Debug                   bool   `json:"debug"`
+	ConnectivityTest        bool   `json:"connectivity_test"`
+	KafkaUrl                string `json:"kafka_url"`
+	ProcessChangeTopic      string `json:"process_change_topic"` //change events of process models are published to this topic; empty to disable
+	RunStartupMigration     bool   `json:"run_startup_migration"`
+	CleanupInterval         string `json:"cleanup_interval"`
+	OutboxDispatchInterval  string `json:"outbox_dispatch_interval"` //interval in which failed permission and kafka side effects are retried
+	TrashRetentionDays      int64  `json:"trash_retention_days"`     //deleted processes are kept in the trash for this many days; 0 deletes processes immediately
+
+	CleanupDryRun          bool    `json:"cleanup_dry_run"`          //the cleanup loop only reports orphaned processes and permissions without removing them
+	CleanupSafetyThreshold float64 `json:"cleanup_safety_threshold"` //percentage of the processes or permissions; cleanups removing more are aborted; 0 disables the check
+	CleanupSafetyMinCount  int64   `json:"cleanup_safety_min_count"` //cleanups removing at most this many processes or permissions are not aborted by CleanupSafetyThreshold
+
+	//administrates processes in permissions-v2 which are administrated only by groups or roles, because permissions-v2 requires an administrating user
+	ServiceUserId string `json:"service_user_id"`
+
+	//handling of processes administrated only by a deleted user: delete | transfer | archive
+	//	delete: the processes are deleted
+	//	transfer: the processes are handed over to UserDeleteFallbackUser and/or UserDeleteFallbackGroup; without UserDeleteFallbackUser they have no owner
+	//	archive: the processes are removed but recoverable by administrators for UserDeleteArchiveDays
+	//published templates of deleted users are deleted, handed over to UserDeleteFallbackUser or kept accordingly; other templates are deleted
+	UserDeletePolicy        string `json:"user_delete_policy"`
+	UserDeleteFallbackUser  string `json:"user_delete_fallback_user"`
+	UserDeleteFallbackGroup string `json:"user_delete_fallback_group"`
+	UserDeleteArchiveDays   int64  `json:"user_delete_archive_days"`
 
 	InitTopics bool
 }
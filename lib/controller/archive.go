@@ -0,0 +1,221 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+)
+
+var ArchiveExportBatchSize int64 = 100
+var ArchiveMaxFileSize int64 = 64 << 20
+
+// ExportProcesses writes a zip archive with <id>.bpmn, <id>.svg and <id>.json (model.ArchiveMetadata) for every process
+// the user may read and that matches options.Ids and options.Search.
+// nothing is written to out if the first batch can not be loaded.
+func (this *Controller) ExportProcesses(token auth.Token, options model.ListOptions, out io.Writer) (err error, code int) {
+	options.Permission = model.READ
+	options.SortBy = "name.asc"
+	options.Offset = 0
+	options.Limit = ArchiveExportBatchSize
+	batch, _, err, code := this.ListProcesses(token, options)
+	if err != nil {
+		return err, code
+	}
+	archive := zip.NewWriter(out)
+	for {
+		for _, process := range batch {
+			err = writeArchiveEntry(archive, process)
+			if err != nil {
+				return err, http.StatusInternalServerError
+			}
+		}
+		if options.Ids != nil || int64(len(batch)) < options.Limit {
+			break
+		}
+		options.Offset = options.Offset + options.Limit
+		batch, _, err, code = this.ListProcesses(token, options)
+		if err != nil {
+			return err, code
+		}
+	}
+	err = archive.Close()
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
+
+func writeArchiveEntry(archive *zip.Writer, process model.Process) error {
+	metadata, err := json.MarshalIndent(model.ArchiveMetadata{
+		Id:          process.Id,
+		Name:        process.Name,
+		Description: process.Description,
+		Publish:     process.Publish,
+		Categories:  process.Categories,
+		Tags:        process.Tags,
+		Folder:      process.Folder,
+	}, "", "    ")
+	if err != nil {
+		return err
+	}
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{name: process.Id + ".bpmn", content: []byte(process.BpmnXml)},
+		{name: process.Id + ".svg", content: []byte(process.SvgXml)},
+		{name: process.Id + ".json", content: metadata},
+	}
+	for _, file := range files {
+		if len(file.content) == 0 {
+			continue
+		}
+		w, err := archive.Create(file.name)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(file.content)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportProcesses creates a process for every .bpmn file in the zip archive.
+// optional files with the same base name and the extensions .svg and .json (model.ArchiveMetadata) are used as svg and metadata.
+// errors of single entries are reported in the result and do not stop the import.
+func (this *Controller) ImportProcesses(token auth.Token, archive []byte) (result []model.ArchiveImportResult, err error, code int) {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	type entry struct {
+		bpmn     *zip.File
+		svg      *zip.File
+		metadata *zip.File
+	}
+	entries := map[string]*entry{}
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		ext := strings.ToLower(path.Ext(file.Name))
+		base := strings.TrimSuffix(file.Name, path.Ext(file.Name))
+		if _, ok := entries[base]; !ok {
+			entries[base] = &entry{}
+		}
+		switch ext {
+		case ".bpmn":
+			entries[base].bpmn = file
+		case ".svg":
+			entries[base].svg = file
+		case ".json":
+			entries[base].metadata = file
+		}
+	}
+	names := []string{}
+	for name, e := range entries {
+		if e.bpmn != nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return result, errors.New("archive contains no .bpmn file"), http.StatusBadRequest
+	}
+	sort.Strings(names)
+
+	result = []model.ArchiveImportResult{}
+	for _, name := range names {
+		e := entries[name]
+		entryResult := model.ArchiveImportResult{Entry: e.bpmn.Name}
+		process, metadata, err := readArchiveEntry(e.bpmn, e.svg, e.metadata)
+		if err != nil {
+			entryResult.Error = err.Error()
+			result = append(result, entryResult)
+			continue
+		}
+		created, err, _ := this.CreateProcess(token, process)
+		if err != nil {
+			entryResult.Error = err.Error()
+			result = append(result, entryResult)
+			continue
+		}
+		entryResult.ProcessId = created.Id
+		entryResult.Name = created.Name
+		if metadata.Publish {
+			_, err, _ = this.UpdateProcessPublic(token, created.Id, model.PublicCommand{Publish: true, Description: metadata.Description, Categories: metadata.Categories})
+			if err != nil {
+				entryResult.Error = "created but not published: " + err.Error()
+			}
+		}
+		result = append(result, entryResult)
+	}
+	return result, nil, http.StatusOK
+}
+
+func readArchiveEntry(bpmnFile *zip.File, svgFile *zip.File, metadataFile *zip.File) (process model.Process, metadata model.ArchiveMetadata, err error) {
+	bpmn, err := readArchiveFile(bpmnFile)
+	if err != nil {
+		return process, metadata, err
+	}
+	process.BpmnXml = string(bpmn)
+	if svgFile != nil {
+		svg, err := readArchiveFile(svgFile)
+		if err != nil {
+			return process, metadata, err
+		}
+		process.SvgXml = string(svg)
+	}
+	if metadataFile != nil {
+		temp, err := readArchiveFile(metadataFile)
+		if err != nil {
+			return process, metadata, err
+		}
+		err = json.Unmarshal(temp, &metadata)
+		if err != nil {
+			return process, metadata, errors.New("invalid metadata: " + err.Error())
+		}
+		process.Name = metadata.Name
+		process.Description = metadata.Description
+		process.Tags = metadata.Tags
+		process.Folder = metadata.Folder
+	}
+	return process, metadata, nil
+}
+
+func readArchiveFile(file *zip.File) ([]byte, error) {
+	if file.UncompressedSize64 > uint64(ArchiveMaxFileSize) {
+		return nil, errors.New(file.Name + " exceeds the max file size")
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(io.LimitReader(reader, ArchiveMaxFileSize))
+}
@@ -18,8 +18,14 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
 	"log"
+	"net/http"
+	"strings"
 	"time"
 )
 
@@ -30,12 +36,17 @@ func (this *Controller) StartCleanupLoop(ctx context.Context, interval time.Dura
 		for {
 			select {
 			case <-ticker.C:
-				start := time.Now()
-				permissionsRemoved, processesRemoved, err := this.Cleanup()
+				report, err := this.Cleanup(this.config.CleanupDryRun, this.config.CleanupSafetyThreshold, this.config.CleanupSafetyMinCount)
 				if err != nil {
 					log.Printf("ERROR: while cleaning up process permissions: %v", err)
 				} else {
-					log.Printf("INFO: cleaned up process permissions in %v, permissions removed: %v, processes removed: %v", time.Now().Sub(start), permissionsRemoved, processesRemoved)
+					log.Printf("INFO: cleaned up process permissions in %vs, orphaned permissions: %v, orphaned processes: %v, applied: %v", report.End-report.Start, len(report.OrphanedResources), len(report.OrphanedProcesses), report.Applied)
+				}
+				purged, err := this.PurgeRemovedProcesses()
+				if err != nil {
+					log.Printf("ERROR: while purging removed processes: %v", err)
+				} else if purged > 0 {
+					log.Printf("INFO: purged %v removed processes", purged)
 				}
 			case <-ctx.Done():
 				return
@@ -44,29 +55,113 @@ func (this *Controller) StartCleanupLoop(ctx context.Context, interval time.Dura
 	}()
 }
 
-func (this *Controller) Cleanup() (permissionsRemoved int, processesRemoved int, err error) {
+// Cleanup removes permissions-v2 resources without stored process and stored processes without permissions-v2 resource.
+// with dryRun the orphans are only reported; the cleanup is aborted if more than safetyThreshold percent
+// and more than safetyMinCount of the processes or permissions would be removed (a safetyThreshold of 0 disables the check).
+// the report is kept and available with GetLastCleanupReport.
+func (this *Controller) Cleanup(dryRun bool, safetyThreshold float64, safetyMinCount int64) (report model.CleanupReport, err error) {
+	report = model.CleanupReport{
+		Start:             time.Now().Unix(),
+		DryRun:            dryRun,
+		OrphanedResources: []string{},
+		OrphanedProcesses: []string{},
+	}
+	defer func() {
+		if err != nil {
+			report.Error = err.Error()
+		}
+		report.End = time.Now().Unix()
+		this.cleanupMux.Lock()
+		defer this.cleanupMux.Unlock()
+		this.lastCleanupReport = &report
+	}()
 	ids, err, _ := this.perm.AdminListResourceIds(client.InternalAdminToken, this.config.ProcessTopic, client.ListOptions{})
 	if err != nil {
-		return permissionsRemoved, processesRemoved, err
+		return report, err
+	}
+	report.ResourceCount = int64(len(ids))
+	report.ProcessCount, err = this.countStoredProcesses()
+	if err != nil {
+		return report, err
 	}
 	missingInDb, missingInPerm, err := this.db.CheckIdList(ids)
 	if err != nil {
-		return permissionsRemoved, processesRemoved, err
+		return report, err
+	}
+	report.OrphanedResources = append(report.OrphanedResources, missingInDb...)
+	for _, id := range missingInPerm {
+		pending, err := this.hasPendingOutboxEntries(id)
+		if err != nil {
+			return report, err
+		}
+		if pending {
+			//permissions will be created by the outbox dispatcher or after a parked entry is resolved
+			continue
+		}
+		report.OrphanedProcesses = append(report.OrphanedProcesses, id)
+	}
+	abortReasons := []string{}
+	if exceedsCleanupThreshold(len(report.OrphanedResources), report.ResourceCount, safetyThreshold, safetyMinCount) {
+		abortReasons = append(abortReasons, fmt.Sprintf("%v of %v permissions would be removed", len(report.OrphanedResources), report.ResourceCount))
+	}
+	if exceedsCleanupThreshold(len(report.OrphanedProcesses), report.ProcessCount, safetyThreshold, safetyMinCount) {
+		abortReasons = append(abortReasons, fmt.Sprintf("%v of %v processes would be removed", len(report.OrphanedProcesses), report.ProcessCount))
+	}
+	report.AbortReason = strings.Join(abortReasons, "; ")
+	if report.AbortReason != "" {
+		return report, errors.New("cleanup aborted by safety threshold: " + report.AbortReason)
 	}
-	for _, id := range missingInDb {
-		permissionsRemoved++
+	if report.DryRun {
+		return report, nil
+	}
+	for _, id := range report.OrphanedResources {
 		err, _ = this.perm.RemoveResource(client.InternalAdminToken, this.config.ProcessTopic, id)
 		if err != nil {
-			return permissionsRemoved, processesRemoved, err
+			return report, err
 		}
 	}
-	for _, id := range missingInPerm {
-		processesRemoved++
+	for _, id := range report.OrphanedProcesses {
+		err, _ = this.deleteProcess(id, "")
+		if err != nil {
+			return report, err
+		}
+	}
+	report.Applied = true
+	return report, nil
+}
+
+// GetLastCleanupReport returns the report of the last Cleanup of this instance; requires the admin role
+func (this *Controller) GetLastCleanupReport(token auth.Token) (result model.CleanupReport, err error, code int) {
+	if !token.IsAdmin() {
+		return result, errors.New("access denied"), http.StatusForbidden
+	}
+	this.cleanupMux.Lock()
+	defer this.cleanupMux.Unlock()
+	if this.lastCleanupReport == nil {
+		return result, errors.New("no cleanup report available"), http.StatusNotFound
+	}
+	return *this.lastCleanupReport, nil, http.StatusOK
+}
+
+func exceedsCleanupThreshold(removed int, total int64, thresholdPercent float64, minCount int64) bool {
+	if thresholdPercent <= 0 || removed == 0 || int64(removed) <= minCount {
+		return false
+	}
+	if total <= 0 {
+		return true
+	}
+	return float64(removed)*100/float64(total) > thresholdPercent
+}
+
+// countStoredProcesses counts the active and the removed processes
+func (this *Controller) countStoredProcesses() (count int64, err error) {
+	for _, removed := range []bool{false, true} {
 		ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
-		err = this.db.DeleteProcess(ctx, id)
+		_, total, err := this.db.ListProcesses(ctx, model.ListOptions{Limit: 1, Removed: removed, Fields: []string{"_id"}})
 		if err != nil {
-			return permissionsRemoved, processesRemoved, err
+			return count, err
 		}
+		count = count + total
 	}
-	return permissionsRemoved, processesRemoved, nil
+	return count, nil
 }
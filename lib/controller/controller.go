@@ -17,16 +17,29 @@
 package controller
 
 import (
+	"errors"
 	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
 	"github.com/SENERGY-Platform/process-model-repository/lib/config"
 	"github.com/SENERGY-Platform/process-model-repository/lib/database"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"sync"
+	"time"
 )
 
-func New(config config.Config, db database.Database) (ctrl *Controller, err error) {
+// New creates a Controller; producer may be nil if no change events should be published
+func New(config config.Config, db database.Database, producer Producer) (ctrl *Controller, err error) {
+	err = checkUserDeletePolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	if config.ServiceUserId == "" {
+		return nil, errors.New("missing service_user_id")
+	}
 	ctrl = &Controller{
-		db:     db,
-		config: config,
-		perm:   client.New(config.PermissionsV2Url),
+		db:       db,
+		config:   config,
+		perm:     client.New(config.PermissionsV2Url),
+		producer: producer,
 	}
 	_, err, _ = ctrl.perm.SetTopic(client.InternalAdminToken, client.Topic{
 		Id:                  config.ProcessTopic,
@@ -43,7 +56,13 @@ func New(config config.Config, db database.Database) (ctrl *Controller, err erro
 }
 
 type Controller struct {
-	db     database.Database
-	config config.Config
-	perm   client.Client
+	db       database.Database
+	config   config.Config
+	perm     client.Client
+	producer Producer
+
+	outboxRetryInterval time.Duration
+
+	cleanupMux        sync.Mutex
+	lastCleanupReport *model.CleanupReport
 }
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"github.com/beevik/etree"
+	"github.com/google/uuid"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"time"
+)
+
+// CopyProcess stores a copy of the process with a new id, owned by the caller.
+// the source has to be readable by the caller or published.
+// copies of readable processes keep the tags and folder of the source.
+func (this *Controller) CopyProcess(token auth.Token, id string) (result model.Process, err error, code int) {
+	source, err, code := this.ReadProcess(token, id, model.READ)
+	fromCatalog := code == http.StatusForbidden
+	if fromCatalog {
+		source, err, code = this.readPublicProcess(id)
+	}
+	if err != nil {
+		return result, err, code
+	}
+	result = source
+	result.Id = uuid.NewString()
+	result.BpmnXml, err = renameBpmnProcessIds(source.BpmnXml)
+	if err != nil {
+		return model.Process{}, err, http.StatusBadRequest
+	}
+	this.deriveFromBpmn(&result)
+	result.Owner = token.GetUserId()
+	result.Publish = false
+	result.PublishDate = ""
+	result.Description = ""
+	result.Categories = []string{}
+	result.Removal = nil
+	if fromCatalog {
+		//tags and folders of other users are meaningless for the caller
+		result.Tags = []string{}
+		result.Folder = ""
+	}
+	result.LastUpdatedUnix = time.Now().Unix()
+	result.Revision = 1
+	err = this.SetProcess(token.GetUserId(), result)
+	if err != nil {
+		ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
+		this.db.DeleteProcess(ctx, result.Id)
+		return model.Process{}, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) readPublicProcess(id string) (result model.Process, err error, code int) {
+	ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
+	result, exists, err := this.db.ReadProcess(ctx, id)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !exists || !result.Publish || result.Removal != nil {
+		return model.Process{}, errors.New("access denied"), http.StatusForbidden
+	}
+	return result, nil, http.StatusOK
+}
+
+// renameBpmnProcessIds replaces the ids of all bpmn:process and bpmn:collaboration elements with new ids
+// and updates the processRef and bpmnElement attributes referencing them
+func renameBpmnProcessIds(bpmn string) (result string, err error) {
+	defer func() {
+		if r := recover(); r != nil && err == nil {
+			log.Printf("%s: %s", r, debug.Stack())
+			err = errors.New(fmt.Sprint("Recovered Error: ", r))
+		}
+	}()
+	doc := etree.NewDocument()
+	err = doc.ReadFromString(bpmn)
+	if err != nil {
+		return "", err
+	}
+	renamed := map[string]string{}
+	for prefix, elements := range map[string][]*etree.Element{
+		"Process_":       doc.FindElements("//bpmn:process"),
+		"Collaboration_": doc.FindElements("//bpmn:collaboration"),
+	} {
+		for _, element := range elements {
+			newId := prefix + uuid.NewString()
+			if oldId := element.SelectAttrValue("id", ""); oldId != "" {
+				renamed[oldId] = newId
+			}
+			element.CreateAttr("id", newId)
+		}
+	}
+	for _, element := range doc.FindElements("//*") {
+		for _, attr := range []string{"processRef", "bpmnElement"} {
+			if newId, ok := renamed[element.SelectAttrValue(attr, "")]; ok {
+				element.CreateAttr(attr, newId)
+			}
+		}
+	}
+	return doc.WriteToString()
+}
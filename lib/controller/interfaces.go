@@ -22,5 +22,5 @@ import (
 
 type Producer interface {
 	PublishProcessPut(id string, userId string, process model.Process) error
-	PublishProcessDelete(id string, userId string) error
+	PublishProcessDelete(id string, userId string, changeTime int64) error
 }
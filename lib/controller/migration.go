@@ -16,10 +16,59 @@
 
 package controller
 
+import (
+	"context"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"log"
+)
+
 func (this *Controller) RunMigrations() error {
 	if !this.config.RunStartupMigration {
 		return nil
 	}
-	//currently no needed migrations
+	return this.migrateProcessMetadata()
+}
+
+// migrateProcessMetadata recomputes the metadata of processes stored before the current model.ProcessMetadataVersion.
+// processes in the trash are migrated too; the revision of the processes is not changed.
+func (this *Controller) migrateProcessMetadata() error {
+	count := 0
+	for _, removed := range []bool{false, true} {
+		migrated, err := this.migrateProcessMetadataOf(removed)
+		if err != nil {
+			return err
+		}
+		count = count + migrated
+	}
+	if count > 0 {
+		log.Println("migrated metadata of", count, "processes")
+	}
 	return nil
 }
+
+func (this *Controller) migrateProcessMetadataOf(removed bool) (count int, err error) {
+	var limit int64 = 100
+	var offset int64 = 0
+	for {
+		ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
+		processes, _, err := this.db.ListProcesses(ctx, model.ListOptions{Limit: limit, Offset: offset, SortBy: "_id.asc", Removed: removed})
+		if err != nil {
+			return count, err
+		}
+		for _, process := range processes {
+			if process.Metadata.Version >= model.ProcessMetadataVersion {
+				continue
+			}
+			process.Metadata = model.ExtractProcessMetadata(process.BpmnXml)
+			err = this.db.SetProcess(ctx, process)
+			if err != nil {
+				return count, err
+			}
+			count++
+		}
+		if int64(len(processes)) < limit {
+			return count, nil
+		}
+		offset = offset + limit
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// ListProcessTags counts the tags of the processes matching the options, which the token has options.Permission for
+func (this *Controller) ListProcessTags(token auth.Token, options model.ListOptions) (result []model.ValueCount, err error, code int) {
+	return this.countAccessibleProcessValues(token, model.ProcessTagsField, options)
+}
+
+// ListProcessFolders counts the processes per folder, which match the options and which the token has options.Permission for.
+// processes are only counted in their own folder, not in the parent folders; the root folder is omitted.
+func (this *Controller) ListProcessFolders(token auth.Token, options model.ListOptions) (result []model.ValueCount, err error, code int) {
+	return this.countAccessibleProcessValues(token, model.ProcessFolderField, options)
+}
+
+func (this *Controller) countAccessibleProcessValues(token auth.Token, field string, options model.ListOptions) (result []model.ValueCount, err error, code int) {
+	options.Ids, err = this.accessibleProcessIds(token, options)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
+	result, err = this.db.CountProcessValues(ctx, field, options)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+// BulkMoveProcesses moves the processes into command.Folder.
+// errors of single processes (e.g. missing write permission) are reported in the result and do not stop the command.
+func (this *Controller) BulkMoveProcesses(token auth.Token, command model.BulkMoveCommand) (result []model.BulkResult, err error, code int) {
+	folder, err := model.NormalizeFolder(command.Folder)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	return this.bulkUpdateProcesses(token, command.Ids, func(process *model.Process) error {
+		process.Folder = folder
+		return nil
+	})
+}
+
+// BulkTagProcesses adds command.Add to and removes command.Remove from the tags of the processes.
+// errors of single processes (e.g. missing write permission) are reported in the result and do not stop the command.
+func (this *Controller) BulkTagProcesses(token auth.Token, command model.BulkTagCommand) (result []model.BulkResult, err error, code int) {
+	add, err := model.NormalizeTags(command.Add)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	remove, err := model.NormalizeTags(command.Remove)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	return this.bulkUpdateProcesses(token, command.Ids, func(process *model.Process) (err error) {
+		tags := append(slices.Clone(process.Tags), add...)
+		tags = slices.DeleteFunc(tags, func(tag string) bool {
+			return slices.Contains(remove, tag)
+		})
+		process.Tags, err = model.NormalizeTags(tags)
+		return err
+	})
+}
+
+// bulkUpdateProcesses applies change to every process; processes unchanged by change are not stored again
+func (this *Controller) bulkUpdateProcesses(token auth.Token, ids []string, change func(process *model.Process) error) (result []model.BulkResult, err error, code int) {
+	if len(ids) > model.MaxBulkSize {
+		return result, fmt.Errorf("more than %v ids", model.MaxBulkSize), http.StatusBadRequest
+	}
+	result = []model.BulkResult{}
+	for _, id := range ids {
+		processResult := model.BulkResult{ProcessId: id}
+		process, err := this.bulkUpdateProcess(token, id, change)
+		if err != nil {
+			processResult.Error = err.Error()
+		} else {
+			processResult.Revision = process.Revision
+		}
+		result = append(result, processResult)
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) bulkUpdateProcess(token auth.Token, id string, change func(process *model.Process) error) (result model.Process, err error) {
+	old, err, _ := this.ReadProcess(token, id, model.WRITE)
+	if err != nil {
+		return result, err
+	}
+	process := old
+	process.Tags = slices.Clone(old.Tags)
+	err = change(&process)
+	if err != nil {
+		return result, err
+	}
+	if process.Folder == old.Folder && slices.Equal(process.Tags, old.Tags) {
+		return old, nil
+	}
+	process.Revision = old.Revision + 1
+	process.LastUpdatedUnix = time.Now().Unix()
+	err = this.SetProcess(token.GetUserId(), process)
+	if err != nil {
+		return result, err
+	}
+	return process, nil
+}
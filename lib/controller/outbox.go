@@ -0,0 +1,221 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"github.com/google/uuid"
+	"log"
+	"net/http"
+	"time"
+)
+
+var OutboxBatchSize int64 = 100
+var OutboxMaxRetryWait = 10 * time.Minute
+var OutboxMaxAttempts int64 = 100 //entries are parked after this many failed attempts
+var OutboxClaimDuration = 2 * TIMEOUT
+
+func (this *Controller) StartOutboxDispatcher(ctx context.Context, interval time.Duration) {
+	this.outboxRetryInterval = interval
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				err := this.DispatchOutbox()
+				if err != nil {
+					log.Printf("ERROR: while dispatching outbox: %v", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// DispatchOutbox executes the side effects of all due outbox entries.
+// entries of a process are executed in order; a failing entry blocks the following entries of the same process.
+func (this *Controller) DispatchOutbox() error {
+	ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
+	entries, err := this.db.ListOutboxEntries(ctx, "", time.Now().Unix(), OutboxBatchSize)
+	if err != nil {
+		return err
+	}
+	blocked := map[string]bool{}
+	for _, entry := range entries {
+		if blocked[entry.ProcessId] {
+			continue
+		}
+		next, err := this.isNextOutboxEntry(entry)
+		if err != nil {
+			return err
+		}
+		if !next || !this.dispatchOutboxEntry(entry) {
+			blocked[entry.ProcessId] = true
+		}
+	}
+	return nil
+}
+
+// newOutboxEntry creates an outbox entry; the background dispatcher ignores it for one retry interval
+// to give the writer the chance to dispatch it with dispatchOutboxEntryIfNext
+func (this *Controller) newOutboxEntry(command model.ProcessChangeCommand, processId string, userId string, process *model.Process) model.OutboxEntry {
+	now := time.Now()
+	return model.OutboxEntry{
+		Id:          uuid.NewString(),
+		Command:     command,
+		ProcessId:   processId,
+		UserId:      userId,
+		Process:     process,
+		Created:     now.UnixNano(),
+		NextAttempt: now.Add(this.outboxRetryInterval).Unix(),
+	}
+}
+
+// dispatchOutboxEntryIfNext dispatches the entry if no older entry of the same process is pending.
+// otherwise, and on failure, the entry is left to the background dispatcher.
+func (this *Controller) dispatchOutboxEntryIfNext(entry model.OutboxEntry) {
+	next, err := this.isNextOutboxEntry(entry)
+	if err != nil {
+		log.Println("WARNING: unable to check pending outbox entries", entry.ProcessId, err)
+		return
+	}
+	if next {
+		this.dispatchOutboxEntry(entry)
+	}
+}
+
+// isNextOutboxEntry checks if the entry is the oldest pending entry of its process
+func (this *Controller) isNextOutboxEntry(entry model.OutboxEntry) (bool, error) {
+	ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
+	pending, err := this.db.ListOutboxEntries(ctx, entry.ProcessId, 0, 1)
+	if err != nil {
+		return false, err
+	}
+	return len(pending) > 0 && pending[0].Id == entry.Id, nil
+}
+
+// dispatchOutboxEntry claims the entry, executes its side effects and removes it from the outbox.
+// returns false if the entry is claimed by another instance or failed;
+// failed entries are rescheduled or, after OutboxMaxAttempts, parked.
+func (this *Controller) dispatchOutboxEntry(entry model.OutboxEntry) (success bool) {
+	ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
+	now := time.Now()
+	claimed, err := this.db.ClaimOutboxEntry(ctx, entry.Id, now.Unix(), now.Add(OutboxClaimDuration).Unix())
+	if err != nil {
+		log.Println("WARNING: unable to claim outbox entry", entry.Id, err)
+		return false
+	}
+	if !claimed {
+		return false
+	}
+	err = this.executeOutboxEntry(entry)
+	ctx, _ = context.WithTimeout(context.Background(), TIMEOUT)
+	if err != nil {
+		log.Println("WARNING: unable to execute outbox entry", entry.Command, entry.ProcessId, err)
+		entry.Attempts++
+		entry.LastError = err.Error()
+		entry.ClaimedUntil = 0
+		wait := time.Duration(entry.Attempts) * this.outboxRetryInterval
+		if wait > OutboxMaxRetryWait {
+			wait = OutboxMaxRetryWait
+		}
+		entry.NextAttempt = time.Now().Add(wait).Unix()
+		if entry.Attempts >= OutboxMaxAttempts {
+			log.Println("ERROR: park outbox entry after", entry.Attempts, "failed attempts:", entry.Id, entry.Command, entry.ProcessId, entry.LastError)
+			entry.Parked = true
+		}
+		err = this.db.SetOutboxEntry(ctx, entry)
+		if err != nil {
+			log.Println("ERROR: unable to reschedule outbox entry", entry.Id, err)
+		}
+		return false
+	}
+	err = this.db.DeleteOutboxEntry(ctx, entry.Id)
+	if err != nil {
+		//entry will be executed again; side effects are idempotent
+		log.Println("ERROR: unable to remove outbox entry", entry.Id, err)
+	}
+	return true
+}
+
+func (this *Controller) executeOutboxEntry(entry model.OutboxEntry) error {
+	switch entry.Command {
+	case model.ProcessChangePut:
+		if entry.Process == nil {
+			return errors.New("missing process in outbox entry")
+		}
+		var err error
+		if entry.Permissions != nil {
+			_, err, _ = this.perm.SetPermission(client.InternalAdminToken, this.config.ProcessTopic, entry.ProcessId, this.permissionsToResource(*entry.Permissions))
+		} else {
+			err = this.ensureInitialPermissions(entry.ProcessId, entry.UserId)
+		}
+		if err != nil {
+			return err
+		}
+		return this.publishProcessPut(entry.UserId, *entry.Process)
+	case model.ProcessChangeDelete:
+		if !entry.KeepResource {
+			err, code := this.perm.RemoveResource(client.InternalAdminToken, this.config.ProcessTopic, entry.ProcessId)
+			if err != nil && code != http.StatusNotFound {
+				return err
+			}
+		}
+		return this.publishProcessDelete(entry.ProcessId, entry.UserId, entry.CreatedUnix())
+	default:
+		return errors.New("unknown outbox command " + string(entry.Command))
+	}
+}
+
+// ensureInitialPermissions gives the owner all permissions if the process is not yet known to permissions-v2
+func (this *Controller) ensureInitialPermissions(id string, owner string) error {
+	_, err, code := this.perm.GetResource(client.InternalAdminToken, this.config.ProcessTopic, id)
+	if err != nil && code != http.StatusNotFound {
+		return err
+	}
+	if code == http.StatusNotFound {
+		_, err, _ = this.perm.SetPermission(client.InternalAdminToken, this.config.ProcessTopic, id, client.ResourcePermissions{
+			UserPermissions: map[string]client.PermissionsMap{
+				owner: {
+					Read:         true,
+					Write:        true,
+					Execute:      true,
+					Administrate: true,
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasPendingOutboxEntries includes parked entries: they still describe changes the permissions-v2 state does not know about yet
+func (this *Controller) hasPendingOutboxEntries(processId string) (bool, error) {
+	ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
+	count, err := this.db.CountOutboxEntries(ctx, processId)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
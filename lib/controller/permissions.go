@@ -0,0 +1,221 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package controller
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"github.com/google/uuid"
+	"net/http"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// ReadProcessPermissions returns the rights of users, groups and roles on the process; requires the administrate right
+func (this *Controller) ReadProcessPermissions(token auth.Token, id string) (result model.ProcessPermissions, err error, code int) {
+	resource, err, code := this.readPermissionResource(token, id)
+	if err != nil {
+		return result, err, code
+	}
+	return this.permissionsFromResource(resource.ResourcePermissions), nil, http.StatusOK
+}
+
+// SetProcessPermissions replaces the rights of users, groups and roles on the process; requires the administrate right.
+// permissions without a user, group or role with the administrate right are refused; role permissions may only be changed by administrators.
+// nil role permissions keep the current role permissions.
+// groups may only be granted or changed by their members or administrators.
+// the process revision, the audit record and the outbox entry, which applies the permissions, are stored in one transaction.
+func (this *Controller) SetProcessPermissions(token auth.Token, id string, permissions model.ProcessPermissions) (result model.ProcessPermissions, err error, code int) {
+	current, err, code := this.readPermissionResource(token, id)
+	if err != nil {
+		return result, err, code
+	}
+	process, err, code := this.ReadProcess(token, id, model.ADMINISTRATE)
+	if err != nil {
+		return result, err, code
+	}
+	if process.Removal != nil {
+		return result, errors.New("process is in the trash"), http.StatusConflict
+	}
+	before := this.permissionsFromResource(current.ResourcePermissions)
+	if permissions.RolePermissions == nil {
+		permissions.RolePermissions = before.RolePermissions
+	}
+	permissions = permissions.Normalized()
+	err = permissions.Validate()
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	if !token.IsAdmin() && !reflect.DeepEqual(before.RolePermissions, permissions.RolePermissions) {
+		//roles may contain every user of the platform
+		return result, errors.New("only administrators may change role permissions"), http.StatusForbidden
+	}
+	if !token.IsAdmin() {
+		//the outbox applies the permissions with the internal admin token: group memberships are checked here
+		for group, rights := range permissions.GroupPermissions {
+			if reflect.DeepEqual(before.GroupPermissions[group], rights) {
+				continue
+			}
+			if !slices.Contains(token.Groups, group) {
+				return result, errors.New("only members may grant permissions to the group " + group), http.StatusForbidden
+			}
+		}
+	}
+	audit, err := newAuditRecord(model.AuditRecord{
+		ProcessId:         id,
+		Action:            model.AuditPermissionsChange,
+		UserId:            token.GetUserId(),
+		PermissionsBefore: &before,
+		PermissionsAfter:  &permissions,
+	})
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	process.Revision = process.Revision + 1
+	process.LastUpdatedUnix = time.Now().Unix()
+	err = this.setProcess(token.GetUserId(), process, &permissions, &audit)
+	if errors.Is(err, ErrRevisionConflict) {
+		return result, err, http.StatusConflict
+	}
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return permissions, nil, http.StatusOK
+}
+
+// TransferProcessOwner changes the owner of the process and grants the new owner all rights; requires the admin role.
+// the rights of the previous owner are replaced by command.PreviousOwnerRights, if set.
+// the change is recorded as one model.AuditRecord with the owners and permissions before and after the transfer.
+func (this *Controller) TransferProcessOwner(token auth.Token, id string, command model.OwnerTransferCommand) (result model.Process, err error, code int) {
+	if !token.IsAdmin() {
+		return result, errors.New("only administrators may transfer processes"), http.StatusForbidden
+	}
+	if command.NewOwner == "" {
+		return result, errors.New("missing new_owner"), http.StatusBadRequest
+	}
+	process, err, code := this.ReadProcess(token, id, model.ADMINISTRATE)
+	if err != nil {
+		return result, err, code
+	}
+	current, err, code := this.readPermissionResource(token, id)
+	if err != nil {
+		return result, err, code
+	}
+	before := this.permissionsFromResource(current.ResourcePermissions)
+	after := before.Normalized()
+	if command.PreviousOwnerRights != nil && process.Owner != "" {
+		after.UserPermissions[process.Owner] = *command.PreviousOwnerRights
+	}
+	after.UserPermissions[command.NewOwner] = allRights
+	return this.changeProcessOwner(token.GetUserId(), process, command.NewOwner, before, after.Normalized())
+}
+
+var allRights = model.PermissionsMap{Read: true, Write: true, Execute: true, Administrate: true}
+
+// changeProcessOwner stores the process with newOwner and records the change in one model.AuditRecord.
+// the process, the audit record and the outbox entry, which replaces the permissions before with after, are stored in one transaction.
+func (this *Controller) changeProcessOwner(userId string, process model.Process, newOwner string, before model.ProcessPermissions, after model.ProcessPermissions) (result model.Process, err error, code int) {
+	audit, err := newAuditRecord(model.AuditRecord{
+		ProcessId:         process.Id,
+		Action:            model.AuditOwnerChange,
+		UserId:            userId,
+		PermissionsBefore: &before,
+		PermissionsAfter:  &after,
+		OwnerBefore:       process.Owner,
+		OwnerAfter:        newOwner,
+	})
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	process.Owner = newOwner
+	process.Revision = process.Revision + 1
+	process.LastUpdatedUnix = time.Now().Unix()
+	err = this.setProcess(userId, process, &after, &audit)
+	if errors.Is(err, ErrRevisionConflict) {
+		return result, err, http.StatusConflict
+	}
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return process, nil, http.StatusOK
+}
+
+// ListProcessAuditRecords lists the audit records of the process, newest first; requires the administrate right
+func (this *Controller) ListProcessAuditRecords(token auth.Token, id string, limit int64, offset int64) (result []model.AuditRecord, total int64, err error, code int) {
+	access, err := this.checkBool(token, this.config.ProcessTopic, id, model.ADMINISTRATE)
+	if err != nil {
+		return result, total, err, http.StatusInternalServerError
+	}
+	if !access {
+		return result, total, errors.New("access denied"), http.StatusForbidden
+	}
+	ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
+	result, total, err = this.db.ListAuditRecords(ctx, id, limit, offset)
+	if err != nil {
+		return result, total, err, http.StatusInternalServerError
+	}
+	return result, total, nil, http.StatusOK
+}
+
+// permissionsFromResource returns the permissions of a permissions-v2 resource without config.ServiceUserId
+func (this *Controller) permissionsFromResource(resource client.ResourcePermissions) model.ProcessPermissions {
+	result := model.ProcessPermissionsFromResource(resource)
+	delete(result.UserPermissions, this.config.ServiceUserId)
+	return result
+}
+
+// permissionsToResource returns the permissions-v2 representation of the permissions.
+// permissions-v2 requires an administrating user: config.ServiceUserId administrates processes administrated only by groups or roles.
+func (this *Controller) permissionsToResource(permissions model.ProcessPermissions) client.ResourcePermissions {
+	result := permissions.ToResource()
+	if !permissions.HasUserAdministrator() {
+		result.UserPermissions[this.config.ServiceUserId] = allRights
+	}
+	return result
+}
+
+func (this *Controller) readPermissionResource(token auth.Token, id string) (result client.Resource, err error, code int) {
+	access, err := this.checkBool(token, this.config.ProcessTopic, id, model.ADMINISTRATE)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !access {
+		return result, errors.New("access denied"), http.StatusForbidden
+	}
+	result, err, code = this.perm.GetResource(client.InternalAdminToken, this.config.ProcessTopic, id)
+	if code == http.StatusNotFound {
+		return result, errors.New("not found"), http.StatusNotFound
+	}
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+// newAuditRecord sets the id and date of the record
+func newAuditRecord(record model.AuditRecord) (model.AuditRecord, error) {
+	id, err := uuid.NewV7() //time ordered; orders records of the same second
+	if err != nil {
+		return record, err
+	}
+	record.Id = id.String()
+	record.Date = time.Now().Unix()
+	return record, nil
+}
@@ -23,11 +23,14 @@ import (
 	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
 	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
 	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"github.com/SENERGY-Platform/process-model-repository/lib/svg"
 	"github.com/beevik/etree"
 	"github.com/google/uuid"
 	"log"
 	"net/http"
 	"runtime/debug"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -37,6 +40,8 @@ import (
 
 const TIMEOUT = 10 * time.Second
 
+var ErrRevisionConflict = errors.New("process has been changed in the meantime")
+
 func (this *Controller) ReadProcess(token auth.Token, id string, action model.AuthAction) (result model.Process, err error, errCode int) {
 	access, err := this.checkBool(token, this.config.ProcessTopic, id, action)
 	if err != nil {
@@ -56,9 +61,29 @@ func (this *Controller) ReadProcess(token auth.Token, id string, action model.Au
 	return result, nil, http.StatusOK
 }
 
-func (this *Controller) ReadAllPublicProcess() (result []model.Process, err error, code int) {
+// ListPublicProcesses lists published processes independent of the permissions of the caller
+func (this *Controller) ListPublicProcesses(options model.ListOptions) (result []model.Process, total int64, err error, code int) {
+	options.Public = true
+	options.Removed = false
+	options.Ids = nil
+	options.SortBy, err = checkListCursor(options)
+	if err != nil {
+		return result, total, err, http.StatusBadRequest
+	}
+	result, total, err = this.listProcesses(options)
+	if err != nil {
+		return result, total, err, http.StatusInternalServerError
+	}
+	return result, total, nil, http.StatusOK
+}
+
+// ListPublicProcessCategories counts the categories of the published processes matching the options
+func (this *Controller) ListPublicProcessCategories(options model.ListOptions) (result []model.ValueCount, err error, code int) {
+	options.Public = true
+	options.Removed = false
+	options.Ids = nil
 	ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
-	result, err = this.db.ReadAllPublicProcesses(ctx)
+	result, err = this.db.CountProcessValues(ctx, model.ProcessCategoriesField, options)
 	if err != nil {
 		return result, err, http.StatusInternalServerError
 	}
@@ -66,37 +91,118 @@ func (this *Controller) ReadAllPublicProcess() (result []model.Process, err erro
 }
 
 func (this *Controller) ListProcesses(token auth.Token, options model.ListOptions) (result []model.Process, total int64, err error, code int) {
-	ids := []string{}
-	//check permissions
+	options.SortBy, err = checkListCursor(options)
+	if err != nil {
+		return result, total, err, http.StatusBadRequest
+	}
+	if options.Ids != nil {
+		options.Limit = 0
+		options.Offset = 0
+		options.After = nil
+	}
+	options.Ids, err = this.accessibleProcessIds(token, options)
+	if err != nil {
+		return result, total, err, http.StatusInternalServerError
+	}
+	result, total, err = this.listProcesses(options)
+	if err != nil {
+		return result, total, err, http.StatusInternalServerError
+	}
+	return result, total, err, http.StatusOK
+}
+
+// accessibleProcessIds returns the ids of options.Ids (or of all processes if options.Ids is nil) with options.Permission for the token.
+// the result is nil (no id filter) for admins without options.Ids.
+func (this *Controller) accessibleProcessIds(token auth.Token, options model.ListOptions) (ids []string, err error) {
 	if options.Ids == nil {
 		if token.IsAdmin() {
-			ids = nil //no auth check for admins -> no id filter
-		} else {
-			ids, err, _ = this.perm.ListAccessibleResourceIds(token.Jwt(), this.config.ProcessTopic, client.ListOptions{}, options.Permission.ToPermission())
-			if err != nil {
-				return result, total, err, http.StatusInternalServerError
-			}
+			return nil, nil //no auth check for admins -> no id filter
 		}
-	} else {
-		options.Limit = 0
-		options.Offset = 0
-		idMap, err, _ := this.perm.CheckMultiplePermissions(token.Jwt(), this.config.ProcessTopic, options.Ids, options.Permission.ToPermission())
-		if err != nil {
-			return result, total, err, http.StatusInternalServerError
+		ids, err, _ = this.perm.ListAccessibleResourceIds(token.Jwt(), this.config.ProcessTopic, client.ListOptions{}, options.Permission.ToPermission())
+		return ids, err
+	}
+	ids = []string{}
+	idMap, err, _ := this.perm.CheckMultiplePermissions(token.Jwt(), this.config.ProcessTopic, options.Ids, options.Permission.ToPermission())
+	if err != nil {
+		return ids, err
+	}
+	for id, ok := range idMap {
+		if ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// checkListCursor returns the sort of the list (default name.asc) and checks if options.After belongs to it
+func checkListCursor(options model.ListOptions) (sortBy string, err error) {
+	sortBy = options.SortBy
+	if sortBy == "" {
+		sortBy = "name.asc"
+	}
+	if options.After != nil {
+		if strings.HasPrefix(sortBy, model.SearchScoreSort+".") {
+			return sortBy, errors.New("continuation tokens are not supported for sort by score")
 		}
-		for id, ok := range idMap {
-			if ok {
-				ids = append(ids, id)
-			}
+		if options.After.SortBy != sortBy {
+			return sortBy, errors.New("continuation token does not match sort")
 		}
 	}
-	options.Ids = ids
+	return sortBy, nil
+}
+
+func (this *Controller) listProcesses(options model.ListOptions) (result []model.Process, total int64, err error) {
+	options.Fields = requiredListFields(options)
 	ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
 	result, total, err = this.db.ListProcesses(ctx, options)
 	if err != nil {
-		return result, total, err, http.StatusInternalServerError
+		return result, total, err
 	}
-	return result, total, err, http.StatusOK
+	if terms := model.SearchTerms(options.Search); len(terms) > 0 {
+		for i := range result {
+			result[i].Highlights = model.HighlightSearchMatches(result[i], terms)
+		}
+	}
+	return result, total, nil
+}
+
+// requiredListFields adds the fields needed for continuation tokens and search highlights to options.Fields.
+// callers are responsible to remove the additional fields from the response.
+func requiredListFields(options model.ListOptions) []string {
+	if options.Fields == nil {
+		return nil
+	}
+	fields := slices.Clone(options.Fields)
+	add := func(field string) {
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	sortField := strings.Split(options.SortBy, ".")[0]
+	if sortField == model.SearchScoreSort {
+		sortField = "name"
+	}
+	add(sortField)
+	if slices.Contains(fields, "highlights") {
+		add("name")
+		add("description")
+		add("metadata")
+	}
+	return fields
+}
+
+// normalizeProcessLabels normalizes the categories, tags and folder of the process
+func normalizeProcessLabels(process *model.Process) (err error) {
+	process.Categories, err = model.NormalizeCategories(process.Categories)
+	if err != nil {
+		return err
+	}
+	process.Tags, err = model.NormalizeTags(process.Tags)
+	if err != nil {
+		return err
+	}
+	process.Folder, err = model.NormalizeFolder(process.Folder)
+	return err
 }
 
 func (this *Controller) checkBool(token auth.Token, kind string, id string, action model.AuthAction) (allowed bool, err error) {
@@ -119,8 +225,15 @@ func (this *Controller) CreateProcess(token auth.Token, process model.Process) (
 	if err != nil {
 		return result, err, http.StatusBadRequest
 	}
+	err = normalizeProcessLabels(&process)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	this.deriveFromBpmn(&process)
 	process.Owner = token.GetUserId()
+	process.Removal = nil
 	process.LastUpdatedUnix = time.Now().Unix()
+	process.Revision = 1
 	err = this.SetProcess(token.GetUserId(), process)
 	if err != nil {
 		ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
@@ -130,7 +243,9 @@ func (this *Controller) CreateProcess(token auth.Token, process model.Process) (
 	return process, nil, http.StatusOK
 }
 
-func (this *Controller) UpdateProcess(token auth.Token, id string, process model.Process) (result model.Process, err error, code int) {
+// UpdateProcess stores process as successor of expectedRevision.
+// expectedRevision may be model.AnyRevision to update whatever revision is currently stored.
+func (this *Controller) UpdateProcess(token auth.Token, id string, process model.Process, expectedRevision int64) (result model.Process, err error, code int) {
 	if process.Id != id {
 		return result, errors.New("path id != process.id"), http.StatusBadRequest
 	}
@@ -149,12 +264,32 @@ func (this *Controller) UpdateProcess(token auth.Token, id string, process model
 	} else {
 		process.Owner = token.GetUserId()
 	}
+	process.Removal = old.Removal
+	if expectedRevision == model.AnyRevision {
+		process.Revision = old.Revision + 1
+	} else {
+		if expectedRevision != old.Revision {
+			return result, ErrRevisionConflict, http.StatusPreconditionFailed
+		}
+		process.Revision = expectedRevision + 1
+	}
 	process.LastUpdatedUnix = time.Now().Unix()
 	err = process.Validate()
 	if err != nil {
 		return result, err, http.StatusBadRequest
 	}
+	err = normalizeProcessLabels(&process)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	this.deriveFromBpmn(&process)
 	err = this.SetProcess(token.GetUserId(), process)
+	if errors.Is(err, ErrRevisionConflict) {
+		if expectedRevision == model.AnyRevision {
+			return result, err, http.StatusConflict
+		}
+		return result, err, http.StatusPreconditionFailed
+	}
 	if err != nil {
 		return result, err, http.StatusInternalServerError
 	}
@@ -162,6 +297,10 @@ func (this *Controller) UpdateProcess(token auth.Token, id string, process model
 }
 
 func (this *Controller) UpdateProcessPublic(token auth.Token, id string, publicCommand model.PublicCommand) (result model.Process, err error, code int) {
+	categories, err := model.NormalizeCategories(publicCommand.Categories)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
 	process, err, code := this.ReadProcess(token, id, model.WRITE)
 	if err != nil {
 		return result, err, code
@@ -169,19 +308,42 @@ func (this *Controller) UpdateProcessPublic(token auth.Token, id string, publicC
 	process.Publish = publicCommand.Publish
 	process.PublishDate = time.Now().String()
 	process.LastUpdatedUnix = time.Now().Unix()
+	process.Revision = process.Revision + 1
 	if process.Publish {
 		process.Description = publicCommand.Description
+		process.Categories = categories
 	} else {
 		process.Description = ""
+		process.Categories = []string{}
 	}
 
 	err = this.SetProcess(token.GetUserId(), process)
+	if errors.Is(err, ErrRevisionConflict) {
+		return result, err, http.StatusConflict
+	}
 	if err != nil {
 		return result, err, http.StatusInternalServerError
 	}
 	return process, nil, http.StatusOK
 }
 
+// ValidateProcess runs the name extraction and validation of CreateProcess/UpdateProcess without storing the process
+func (this *Controller) ValidateProcess(process model.Process) (result model.ValidationResult, err error, code int) {
+	result.Name = process.Name
+	result.Findings = []model.ValidationFinding{}
+	if result.Name == "" {
+		result.Name, err = this.GetProcessModelName(process.BpmnXml)
+		if err != nil {
+			result.Findings = append(result.Findings, model.ValidationFinding{Severity: model.SeverityError, Message: "unable to extract process name: " + err.Error()})
+		}
+	}
+	result.Findings = append(result.Findings, model.ValidateBpmn(process.BpmnXml)...)
+	result.Valid = !model.ContainsValidationErrors(result.Findings)
+	return result, nil, http.StatusOK
+}
+
+// DeleteProcess moves the process into the trash, from where it is purged after config.TrashRetentionDays.
+// processes already in the trash are deleted immediately.
 func (this *Controller) DeleteProcess(token auth.Token, id string) (error, int) {
 	access, err := this.checkBool(token, this.config.ProcessTopic, id, model.ADMINISTRATE)
 	if err != nil {
@@ -190,22 +352,82 @@ func (this *Controller) DeleteProcess(token auth.Token, id string) (error, int)
 	if !access {
 		return errors.New("access denied"), http.StatusForbidden
 	}
-	return this.deleteProcess(id)
+	if this.config.TrashRetentionDays <= 0 {
+		return this.deleteProcess(id, token.GetUserId())
+	}
+	ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
+	process, exists, err := this.db.ReadProcess(ctx, id)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	if !exists || process.Removal != nil {
+		//deleting a process in the trash removes it for good
+		return this.deleteProcess(id, token.GetUserId())
+	}
+	now := time.Now()
+	return this.removeProcess(process, model.ProcessRemoval{
+		Reason: model.RemovalReasonDelete,
+		UserId: token.GetUserId(),
+		Date:   now.Unix(),
+		Until:  now.Add(time.Duration(this.config.TrashRetentionDays) * 24 * time.Hour).Unix(),
+	})
 }
 
-func (this *Controller) deleteProcess(id string) (error, int) {
-	ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
-	err, _ := this.perm.RemoveResource(client.InternalAdminToken, this.config.ProcessTopic, id)
+func (this *Controller) deleteProcess(id string, userId string) (error, int) {
+	timeout, _ := context.WithTimeout(context.Background(), TIMEOUT)
+	ctx, closeTransaction, err := this.db.Transaction(timeout)
 	if err != nil {
 		return err, http.StatusInternalServerError
 	}
 	err = this.db.DeleteProcess(ctx, id)
 	if err != nil {
+		closeTransaction(false)
+		return err, http.StatusInternalServerError
+	}
+	entry := this.newOutboxEntry(model.ProcessChangeDelete, id, userId, nil)
+	err = this.db.SetOutboxEntry(ctx, entry)
+	if err != nil {
+		closeTransaction(false)
 		return err, http.StatusInternalServerError
 	}
+	err = closeTransaction(true)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	this.dispatchOutboxEntryIfNext(entry)
 	return nil, http.StatusOK
 }
 
+// ReadProcessSvg returns the stored svg of the process or renders one from the bpmn diagram interchange if none is stored
+func (this *Controller) ReadProcessSvg(token auth.Token, id string) (result string, err error, code int) {
+	process, err, code := this.ReadProcess(token, id, model.READ)
+	if err != nil {
+		return result, err, code
+	}
+	if process.SvgXml != "" {
+		return process.SvgXml, nil, http.StatusOK
+	}
+	result, err = svg.Render(process.BpmnXml)
+	if err != nil {
+		return result, err, http.StatusNotFound
+	}
+	return result, nil, http.StatusOK
+}
+
+// deriveFromBpmn sets the process metadata and renders a svg from the bpmn if the client did not provide one
+func (this *Controller) deriveFromBpmn(process *model.Process) {
+	process.Metadata = model.ExtractProcessMetadata(process.BpmnXml)
+	process.Highlights = nil
+	if process.SvgXml != "" {
+		return
+	}
+	var err error
+	process.SvgXml, err = svg.Render(process.BpmnXml)
+	if err != nil {
+		log.Println("WARNING: unable to render svg for process", process.Id, err)
+	}
+}
+
 func (this *Controller) GetProcessModelName(bpmn string) (name string, err error) {
 	defer func() {
 		if r := recover(); r != nil && err == nil {
@@ -227,33 +449,106 @@ func (this *Controller) GetProcessModelName(bpmn string) (name string, err error
 	return name, nil
 }
 
-func (this *Controller) SetProcess(owner string, process model.Process) error {
-	ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
+// SetProcess stores process as successor of the revision process.Revision-1.
+// returns ErrRevisionConflict if the stored process has another revision.
+// processes stored before revisions existed (revision 0) are kept as revision 0 before they are overwritten.
+// the permissions-v2 and kafka side effects are recorded in the outbox in the same transaction
+// and executed after the commit or, on failure, by the outbox dispatcher.
+// removed processes (see model.Process.Removal) are published as deleted but keep their permissions to allow a restore.
+func (this *Controller) SetProcess(owner string, process model.Process) (err error) {
+	return this.setProcess(owner, process, nil, nil)
+}
+
+// setProcess is SetProcess with optional permissions, which replace the permissions in permissions-v2 as part of the outbox entry,
+// and an optional audit record, which is stored in the same transaction
+func (this *Controller) setProcess(owner string, process model.Process, permissions *model.ProcessPermissions, audit *model.AuditRecord) (err error) {
 	if process.LastUpdatedUnix == 0 {
 		process.LastUpdatedUnix = time.Now().Unix()
 	}
-	err := this.db.SetProcess(ctx, process)
+	timeout, _ := context.WithTimeout(context.Background(), TIMEOUT)
+	ctx, closeTransaction, err := this.db.Transaction(timeout)
 	if err != nil {
 		return err
 	}
-	_, err, code := this.perm.GetResource(client.InternalAdminToken, this.config.ProcessTopic, process.Id)
-	if err != nil && code != http.StatusNotFound {
+	committed := false
+	defer func() {
+		if !committed {
+			closeTransaction(false)
+		}
+	}()
+	if process.Revision == 1 {
+		err = this.snapshotLegacyProcess(ctx, process.Id)
+		if err != nil {
+			return err
+		}
+	}
+	ok, err := this.db.SetProcessIfRevision(ctx, process, process.Revision-1)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrRevisionConflict
+	}
+	err = this.db.SetProcessRevision(ctx, model.ProcessRevision{
+		ProcessId: process.Id,
+		Revision:  process.Revision,
+		UserId:    owner,
+		Date:      process.LastUpdatedUnix,
+		Process:   process,
+	})
+	if err != nil {
 		return err
 	}
-	if code == http.StatusNotFound {
-		_, err, _ = this.perm.SetPermission(client.InternalAdminToken, this.config.ProcessTopic, process.Id, client.ResourcePermissions{
-			UserPermissions: map[string]client.PermissionsMap{
-				owner: {
-					Read:         true,
-					Write:        true,
-					Execute:      true,
-					Administrate: true,
-				},
-			},
-		})
+	entry := this.newOutboxEntry(model.ProcessChangePut, process.Id, owner, &process)
+	entry.Permissions = permissions
+	if process.Removal != nil {
+		entry = this.newOutboxEntry(model.ProcessChangeDelete, process.Id, owner, nil)
+		entry.KeepResource = true
+	}
+	err = this.db.SetOutboxEntry(ctx, entry)
+	if err != nil {
+		return err
+	}
+	if audit != nil {
+		err = this.db.SetAuditRecord(ctx, *audit)
 		if err != nil {
 			return err
 		}
 	}
+	committed = true
+	err = closeTransaction(true)
+	if err != nil {
+		return err
+	}
+	this.dispatchOutboxEntryIfNext(entry)
 	return nil
 }
+
+func (this *Controller) publishProcessPut(userId string, process model.Process) error {
+	if this.producer == nil {
+		return nil
+	}
+	return this.producer.PublishProcessPut(process.Id, userId, process)
+}
+
+func (this *Controller) publishProcessDelete(id string, userId string, changeTime int64) error {
+	if this.producer == nil {
+		return nil
+	}
+	return this.producer.PublishProcessDelete(id, userId, changeTime)
+}
+
+// snapshotLegacyProcess stores a process without revision (stored before revisions existed) as revision 0
+func (this *Controller) snapshotLegacyProcess(ctx context.Context, id string) error {
+	legacy, exists, err := this.db.ReadProcess(ctx, id)
+	if err != nil || !exists || legacy.Revision != 0 {
+		return err
+	}
+	return this.db.SetProcessRevision(ctx, model.ProcessRevision{
+		ProcessId: legacy.Id,
+		Revision:  0,
+		UserId:    legacy.Owner,
+		Date:      legacy.LastUpdatedUnix,
+		Process:   legacy,
+	})
+}
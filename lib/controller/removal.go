@@ -0,0 +1,88 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"net/http"
+	"time"
+)
+
+// RestoreProcess takes a process out of the trash or the user delete archive; requires the administrate right
+func (this *Controller) RestoreProcess(token auth.Token, id string) (result model.Process, err error, code int) {
+	process, err, code := this.ReadProcess(token, id, model.ADMINISTRATE)
+	if err != nil {
+		return result, err, code
+	}
+	if process.Removal == nil {
+		return result, errors.New("process is not removed"), http.StatusBadRequest
+	}
+	process.Removal = nil
+	process.Revision = process.Revision + 1
+	process.LastUpdatedUnix = time.Now().Unix()
+	err = this.SetProcess(token.GetUserId(), process)
+	if errors.Is(err, ErrRevisionConflict) {
+		return result, err, http.StatusConflict
+	}
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return process, nil, http.StatusOK
+}
+
+// removeProcess hides the process until removal.Until; the permissions are kept to allow a restore
+func (this *Controller) removeProcess(process model.Process, removal model.ProcessRemoval) (error, int) {
+	process.Removal = &removal
+	process.Revision = process.Revision + 1
+	process.LastUpdatedUnix = time.Now().Unix()
+	err := this.SetProcess(removal.UserId, process)
+	if errors.Is(err, ErrRevisionConflict) {
+		return err, http.StatusConflict
+	}
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
+
+// PurgeRemovedProcesses deletes removed processes whose removal expired
+func (this *Controller) PurgeRemovedProcesses() (purged int, err error) {
+	ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
+	removed, _, err := this.db.ListProcesses(ctx, model.ListOptions{
+		Removed:      true,
+		WithoutTotal: true,
+		Fields:       []string{"_id", "removal"},
+	})
+	if err != nil {
+		return purged, err
+	}
+	now := time.Now().Unix()
+	for _, process := range removed {
+		if process.Removal == nil || !process.Removal.Expired(now) {
+			continue
+		}
+		err, _ = this.deleteProcess(process.Id, "")
+		if err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
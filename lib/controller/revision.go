@@ -0,0 +1,79 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"net/http"
+)
+
+func (this *Controller) ListProcessRevisions(token auth.Token, id string, limit int64, offset int64) (result []model.ProcessRevision, total int64, err error, code int) {
+	access, err := this.checkBool(token, this.config.ProcessTopic, id, model.READ)
+	if err != nil {
+		return result, total, err, http.StatusInternalServerError
+	}
+	if !access {
+		return result, total, errors.New("access denied"), http.StatusForbidden
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	defer cancel()
+	result, total, err = this.db.ListProcessRevisions(ctx, id, limit, offset)
+	if err != nil {
+		return result, total, err, http.StatusInternalServerError
+	}
+	return result, total, nil, http.StatusOK
+}
+
+func (this *Controller) ReadProcessRevision(token auth.Token, id string, revision int64) (result model.ProcessRevision, err error, code int) {
+	access, err := this.checkBool(token, this.config.ProcessTopic, id, model.READ)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !access {
+		return result, errors.New("access denied"), http.StatusForbidden
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	defer cancel()
+	result, exists, err := this.db.ReadProcessRevision(ctx, id, revision)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !exists {
+		return result, errors.New("not found"), http.StatusNotFound
+	}
+	return result, nil, http.StatusOK
+}
+
+// RestoreProcessRevision stores the model content (name, bpmn and svg) of an older revision as a new revision.
+// publish state and description of the current process are kept.
+func (this *Controller) RestoreProcessRevision(token auth.Token, id string, revision int64) (result model.Process, err error, code int) {
+	current, err, code := this.ReadProcess(token, id, model.WRITE)
+	if err != nil {
+		return result, err, code
+	}
+	old, err, code := this.ReadProcessRevision(token, id, revision)
+	if err != nil {
+		return result, err, code
+	}
+	current.Name = old.Process.Name
+	current.BpmnXml = old.Process.BpmnXml
+	current.SvgXml = old.Process.SvgXml
+	return this.UpdateProcess(token, id, current, current.Revision)
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+func (this *Controller) ReadTemplate(token auth.Token, id string) (result model.ProcessTemplate, err error, code int) {
+	ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	defer cancel()
+	result, exists, err := this.db.ReadTemplate(ctx, id)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !exists {
+		return result, errors.New("not found"), http.StatusNotFound
+	}
+	if !token.IsAdmin() && result.Owner != token.GetUserId() && !result.Publish {
+		return model.ProcessTemplate{}, errors.New("access denied"), http.StatusForbidden
+	}
+	return result, nil, http.StatusOK
+}
+
+// ListTemplates lists the templates of the user and all published templates; admins get all templates
+func (this *Controller) ListTemplates(token auth.Token, options model.TemplateListOptions) (result []model.ProcessTemplate, total int64, err error, code int) {
+	if token.IsAdmin() {
+		options.Owner = ""
+	} else {
+		options.Owner = token.GetUserId()
+		options.IncludePublished = true
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	defer cancel()
+	result, total, err = this.db.ListTemplates(ctx, options)
+	if err != nil {
+		return result, total, err, http.StatusInternalServerError
+	}
+	return result, total, nil, http.StatusOK
+}
+
+func (this *Controller) CreateTemplate(token auth.Token, template model.ProcessTemplate) (result model.ProcessTemplate, err error, code int) {
+	template.Id = uuid.NewString()
+	template.Owner = token.GetUserId()
+	template.LastUpdatedUnix = time.Now().Unix()
+	err = template.Validate()
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	defer cancel()
+	err = this.db.SetTemplate(ctx, template)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return template, nil, http.StatusOK
+}
+
+func (this *Controller) UpdateTemplate(token auth.Token, id string, template model.ProcessTemplate) (result model.ProcessTemplate, err error, code int) {
+	if template.Id != id {
+		return result, errors.New("path id != template.id"), http.StatusBadRequest
+	}
+	old, err, code := this.ReadTemplate(token, id)
+	if err != nil {
+		return result, err, code
+	}
+	if !token.IsAdmin() && old.Owner != token.GetUserId() {
+		return result, errors.New("access denied"), http.StatusForbidden
+	}
+	template.Owner = old.Owner
+	template.LastUpdatedUnix = time.Now().Unix()
+	err = template.Validate()
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	defer cancel()
+	err = this.db.SetTemplate(ctx, template)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return template, nil, http.StatusOK
+}
+
+func (this *Controller) DeleteTemplate(token auth.Token, id string) (error, int) {
+	old, err, code := this.ReadTemplate(token, id)
+	if err != nil {
+		return err, code
+	}
+	if !token.IsAdmin() && old.Owner != token.GetUserId() {
+		return errors.New("access denied"), http.StatusForbidden
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	defer cancel()
+	err = this.db.DeleteTemplate(ctx, id)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
+
+// InstantiateTemplate replaces the template placeholders with the given parameter values
+// and creates a new process, owned by the caller, from the result.
+// the bpmn:process and bpmn:collaboration ids are replaced like in CopyProcess, so instances of a template do not collide on deployment.
+func (this *Controller) InstantiateTemplate(token auth.Token, id string, instantiation model.TemplateInstantiation) (result model.Process, err error, code int) {
+	template, err, code := this.ReadTemplate(token, id)
+	if err != nil {
+		return result, err, code
+	}
+	bpmn, svg, err := template.Apply(instantiation.Parameters)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	bpmn, err = renameBpmnProcessIds(bpmn)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	return this.CreateProcess(token, model.Process{
+		Name:        instantiation.Name,
+		Description: instantiation.Description,
+		BpmnXml:     bpmn,
+		SvgXml:      svg,
+	})
+}
+
+// handleTemplatesOfDeletedUser deletes the templates of a deleted user.
+// published templates may be used by other users and follow config.UserDeletePolicy:
+// they are handed over to config.UserDeleteFallbackUser (transfer) or kept for administrators (archive).
+func (this *Controller) handleTemplatesOfDeletedUser(owner string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	defer cancel()
+	var offset int64 = 0
+	for {
+		templates, _, err := this.db.ListTemplates(ctx, model.TemplateListOptions{Owner: owner, Limit: 100, Offset: offset})
+		if err != nil {
+			return err
+		}
+		if len(templates) == 0 {
+			return nil
+		}
+		for _, template := range templates {
+			switch {
+			case !template.Publish || this.config.UserDeletePolicy == "" || this.config.UserDeletePolicy == UserDeletePolicyDelete:
+				err = this.db.DeleteTemplate(ctx, template.Id)
+			case this.config.UserDeletePolicy == UserDeletePolicyTransfer && this.config.UserDeleteFallbackUser != "":
+				template.Owner = this.config.UserDeleteFallbackUser
+				template.LastUpdatedUnix = time.Now().Unix()
+				err = this.db.SetTemplate(ctx, template)
+			default:
+				offset = offset + 1
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -1,11 +1,41 @@
 package controller
 
 import (
+	"context"
+	"errors"
 	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
 	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
+	"github.com/SENERGY-Platform/process-model-repository/lib/config"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
 	"slices"
+	"time"
 )
 
+const (
+	UserDeletePolicyDelete   = "delete"
+	UserDeletePolicyTransfer = "transfer"
+	UserDeletePolicyArchive  = "archive"
+)
+
+func checkUserDeletePolicy(config config.Config) error {
+	switch config.UserDeletePolicy {
+	case "", UserDeletePolicyDelete:
+		return nil
+	case UserDeletePolicyTransfer:
+		if config.UserDeleteFallbackUser == "" && config.UserDeleteFallbackGroup == "" {
+			return errors.New("user_delete_policy transfer requires user_delete_fallback_user or user_delete_fallback_group")
+		}
+		return nil
+	case UserDeletePolicyArchive:
+		if config.UserDeleteArchiveDays <= 0 {
+			return errors.New("user_delete_policy archive requires positive user_delete_archive_days")
+		}
+		return nil
+	default:
+		return errors.New("unknown user_delete_policy " + config.UserDeletePolicy)
+	}
+}
+
 func (this *Controller) HandleUserDelete(userId string) error {
 	processModelResource := "processmodel"
 	token, err := auth.CreateToken("process-model-repo", userId)
@@ -17,19 +47,76 @@ func (this *Controller) HandleUserDelete(userId string) error {
 		return err
 	}
 	for _, id := range processModelsToDelete {
-		err, _ = this.deleteProcess(id)
+		err = this.handleProcessOfDeletedUser(id, userId)
 		if err != nil {
 			return err
 		}
 	}
 	for _, r := range userToDeleteFromProcessModels {
-		delete(r.UserPermissions, userId)
-		_, err, _ = this.perm.SetPermission(client.InternalAdminToken, this.config.ProcessTopic, r.Id, r.ResourcePermissions)
+		//processes kept alive only by a group or role administrator are administrated by config.ServiceUserId in permissions-v2
+		permissions := this.permissionsFromResource(r.ResourcePermissions)
+		delete(permissions.UserPermissions, userId)
+		_, err, _ = this.perm.SetPermission(client.InternalAdminToken, this.config.ProcessTopic, r.Id, this.permissionsToResource(permissions))
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+	return this.handleTemplatesOfDeletedUser(userId)
+}
+
+// handleProcessOfDeletedUser deletes, transfers or archives a process administrated only by the deleted user, depending on config.UserDeletePolicy
+func (this *Controller) handleProcessOfDeletedUser(id string, userId string) error {
+	if this.config.UserDeletePolicy == "" || this.config.UserDeletePolicy == UserDeletePolicyDelete {
+		err, _ := this.deleteProcess(id, userId)
+		return err
+	}
+	ctx, _ := context.WithTimeout(context.Background(), TIMEOUT)
+	process, exists, err := this.db.ReadProcess(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		//only the permissions are left
+		err, _ = this.deleteProcess(id, userId)
+		return err
+	}
+	switch this.config.UserDeletePolicy {
+	case UserDeletePolicyTransfer:
+		return this.transferProcessOfDeletedUser(process, userId)
+	case UserDeletePolicyArchive:
+		now := time.Now()
+		err, _ = this.removeProcess(process, model.ProcessRemoval{
+			Reason: model.RemovalReasonUserDelete,
+			UserId: userId,
+			Date:   now.Unix(),
+			Until:  now.Add(time.Duration(this.config.UserDeleteArchiveDays) * 24 * time.Hour).Unix(),
+		})
+		return err
+	default:
+		return checkUserDeletePolicy(this.config)
+	}
+}
+
+// transferProcessOfDeletedUser hands the process over to the configured fallback user and group.
+// the rights of the deleted user are removed; without fallback user the process has no owner
+// and is administrated by config.ServiceUserId in permissions-v2.
+func (this *Controller) transferProcessOfDeletedUser(process model.Process, userId string) error {
+	resource, err, _ := this.perm.GetResource(client.InternalAdminToken, this.config.ProcessTopic, process.Id)
+	if err != nil {
+		return err
+	}
+	before := this.permissionsFromResource(resource.ResourcePermissions)
+	after := before.Normalized()
+	delete(after.UserPermissions, userId)
+	if this.config.UserDeleteFallbackGroup != "" {
+		after.GroupPermissions[this.config.UserDeleteFallbackGroup] = allRights
+	}
+	owner := this.config.UserDeleteFallbackUser
+	if owner != "" {
+		after.UserPermissions[owner] = allRights
+	}
+	_, err, _ = this.changeProcessOwner("", process, owner, before, after)
+	return err
 }
 
 type PermSearchElement struct {
@@ -49,19 +136,20 @@ type PermissionHolders struct {
 
 var ResourcesEffectedByUserDelete_BATCH_SIZE int64 = 1000
 
-func containsOtherAdmin(m map[string]client.PermissionsMap, notThisKey string) bool {
-	for k, v := range m {
-		if k != notThisKey && v.Administrate {
-			return true
-		}
-	}
-	return false
+// isAdministratedWithout checks if the resource keeps an administrating user, group or role without the user (see model.ProcessPermissions.Validate)
+func (this *Controller) isAdministratedWithout(resource client.Resource, userId string) bool {
+	permissions := this.permissionsFromResource(resource.ResourcePermissions)
+	delete(permissions.UserPermissions, userId)
+	return permissions.Validate() == nil
 }
 
+// ResourcesEffectedByUserDelete returns the resources administrated only by the user, which have to be deleted,
+// and the resources on which the user has to be removed from the user permissions.
+// resources with another administrating user, group or role are kept.
 func (this *Controller) ResourcesEffectedByUserDelete(token auth.Token, resource string) (deleteResourceIds []string, deleteUserFromResource []client.Resource, err error) {
 	userid := token.GetUserId()
 	err = this.iterateResource(token, resource, ResourcesEffectedByUserDelete_BATCH_SIZE, client.Administrate, func(element client.Resource) {
-		if containsOtherAdmin(element.UserPermissions, userid) {
+		if this.isAdministratedWithout(element, userid) {
 			deleteUserFromResource = append(deleteUserFromResource, element)
 		} else {
 			deleteResourceIds = append(deleteResourceIds, element.Id)
@@ -71,10 +159,14 @@ func (this *Controller) ResourcesEffectedByUserDelete(token auth.Token, resource
 		return
 	}
 
+	//resources administrated only by the user are deleted and must not be updated afterwards
+	handled := func(id string) bool {
+		return slices.Contains(deleteResourceIds, id) || slices.ContainsFunc(deleteUserFromResource, func(resource client.Resource) bool {
+			return resource.Id == id
+		})
+	}
 	err = this.iterateResource(token, resource, ResourcesEffectedByUserDelete_BATCH_SIZE, client.Read, func(element client.Resource) {
-		if !slices.ContainsFunc(deleteUserFromResource, func(resource client.Resource) bool {
-			return resource.Id == element.Id
-		}) {
+		if !handled(element.Id) {
 			deleteUserFromResource = append(deleteUserFromResource, element)
 		}
 	})
@@ -82,9 +174,7 @@ func (this *Controller) ResourcesEffectedByUserDelete(token auth.Token, resource
 		return
 	}
 	err = this.iterateResource(token, resource, ResourcesEffectedByUserDelete_BATCH_SIZE, client.Write, func(element client.Resource) {
-		if !slices.ContainsFunc(deleteUserFromResource, func(resource client.Resource) bool {
-			return resource.Id == element.Id
-		}) {
+		if !handled(element.Id) {
 			deleteUserFromResource = append(deleteUserFromResource, element)
 		}
 	})
@@ -92,9 +182,7 @@ func (this *Controller) ResourcesEffectedByUserDelete(token auth.Token, resource
 		return
 	}
 	err = this.iterateResource(token, resource, ResourcesEffectedByUserDelete_BATCH_SIZE, client.Execute, func(element client.Resource) {
-		if !slices.ContainsFunc(deleteUserFromResource, func(resource client.Resource) bool {
-			return resource.Id == element.Id
-		}) {
+		if !handled(element.Id) {
 			deleteUserFromResource = append(deleteUserFromResource, element)
 		}
 	})
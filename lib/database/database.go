@@ -18,10 +18,23 @@ package database
 
 import (
 	"context"
+	"errors"
 	"github.com/SENERGY-Platform/process-model-repository/lib/config"
+	"github.com/SENERGY-Platform/process-model-repository/lib/database/memory"
 	"github.com/SENERGY-Platform/process-model-repository/lib/database/mongo"
+	"github.com/SENERGY-Platform/process-model-repository/lib/database/postgres"
 )
 
+// New creates the database selected by conf.DatabaseType (mongo | postgres | memory; default mongo)
 func New(ctx context.Context, conf config.Config) (db Database, err error) {
-	return mongo.New(ctx, conf)
+	switch conf.DatabaseType {
+	case "", "mongo":
+		return mongo.New(ctx, conf)
+	case "postgres":
+		return postgres.New(ctx, conf)
+	case "memory":
+		return memory.New(conf), nil
+	default:
+		return nil, errors.New("unknown database type: " + conf.DatabaseType)
+	}
 }
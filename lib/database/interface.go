@@ -22,10 +22,31 @@ import (
 )
 
 type Database interface {
+	Transaction(ctx context.Context) (resultCtx context.Context, close func(success bool) error, err error) //no-op transaction if the database is not able to handle transactions
+
 	ReadProcess(ctx context.Context, id string) (result model.Process, exists bool, err error)
-	ReadAllPublicProcesses(ctx context.Context) ([]model.Process, error)
 	SetProcess(ctx context.Context, process model.Process) error
+	SetProcessIfRevision(ctx context.Context, process model.Process, expectedRevision int64) (ok bool, err error) //expectedRevision 0 creates the process if missing; ok == false if the stored revision differs
 	DeleteProcess(ctx context.Context, id string) error
 	ListProcesses(ctx context.Context, options model.ListOptions) ([]model.Process, int64, error)
-	CheckIdList(ids []string) (missingInDb []string, missingInInput []string, err error)
+	CountProcessValues(ctx context.Context, field string, options model.ListOptions) ([]model.ValueCount, error) //counts the values of the string or string list field (json/bson key) of processes matching the filters of options; empty strings are not counted; sorted by value
+	CheckIdList(ids []string) (missingInDb []string, missingInInput []string, err error)                         //removed processes (see model.ProcessRemoval) are not reported in missingInInput
+
+	SetProcessRevision(ctx context.Context, revision model.ProcessRevision) error
+	ReadProcessRevision(ctx context.Context, processId string, revision int64) (result model.ProcessRevision, exists bool, err error)
+	ListProcessRevisions(ctx context.Context, processId string, limit int64, offset int64) (result []model.ProcessRevision, total int64, err error) //newest first; without bpmn and svg content
+
+	ReadTemplate(ctx context.Context, id string) (result model.ProcessTemplate, exists bool, err error)
+	SetTemplate(ctx context.Context, template model.ProcessTemplate) error
+	DeleteTemplate(ctx context.Context, id string) error
+	ListTemplates(ctx context.Context, options model.TemplateListOptions) (result []model.ProcessTemplate, total int64, err error)
+
+	SetOutboxEntry(ctx context.Context, entry model.OutboxEntry) error
+	DeleteOutboxEntry(ctx context.Context, id string) error
+	ListOutboxEntries(ctx context.Context, processId string, due int64, limit int64) ([]model.OutboxEntry, error) //oldest first without parked entries; all processes if processId is empty; only unclaimed entries with next_attempt <= due if due > 0
+	ClaimOutboxEntry(ctx context.Context, id string, now int64, until int64) (claimed bool, err error)            //sets claimed_until if the entry is not parked and not claimed at now
+	CountOutboxEntries(ctx context.Context, processId string) (count int64, err error)                            //including parked entries
+
+	SetAuditRecord(ctx context.Context, record model.AuditRecord) error
+	ListAuditRecords(ctx context.Context, processId string, limit int64, offset int64) (result []model.AuditRecord, total int64, err error) //newest first
 }
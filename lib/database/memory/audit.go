@@ -0,0 +1,57 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package memory
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"sort"
+)
+
+func (this *Memory) SetAuditRecord(ctx context.Context, record model.AuditRecord) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.audit[record.Id] = clone(record)
+	return nil
+}
+
+func (this *Memory) ListAuditRecords(ctx context.Context, processId string, limit int64, offset int64) (result []model.AuditRecord, total int64, err error) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	all := []model.AuditRecord{}
+	for _, record := range this.audit {
+		if record.ProcessId == processId {
+			all = append(all, record)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Date != all[j].Date {
+			return all[i].Date > all[j].Date
+		}
+		return all[i].Id > all[j].Id
+	})
+	result = []model.AuditRecord{}
+	for i, record := range all {
+		if int64(i) < offset {
+			continue
+		}
+		if limit > 0 && int64(len(result)) >= limit {
+			break
+		}
+		result = append(result, clone(record))
+	}
+	return result, int64(len(all)), nil
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-model-repository/lib/config"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"github.com/google/uuid"
+	"sync"
+)
+
+// Memory is a non-persistent implementation of database.Database for tests and local development.
+// it mirrors the semantics of the mongo implementation.
+type Memory struct {
+	config    config.Config
+	mux       sync.RWMutex
+	processes map[string]model.Process
+	revisions map[string]map[int64]model.ProcessRevision
+	templates map[string]model.ProcessTemplate
+	outbox    map[string]model.OutboxEntry
+	audit     map[string]model.AuditRecord
+}
+
+func New(conf config.Config) *Memory {
+	return &Memory{
+		config:    conf,
+		processes: map[string]model.Process{},
+		revisions: map[string]map[int64]model.ProcessRevision{},
+		templates: map[string]model.ProcessTemplate{},
+		outbox:    map[string]model.OutboxEntry{},
+		audit:     map[string]model.AuditRecord{},
+	}
+}
+
+func (this *Memory) CreateId() string {
+	return uuid.NewString()
+}
+
+// Transaction is a no-op; like mongo without replication set, writes are applied immediately
+func (this *Memory) Transaction(ctx context.Context) (resultCtx context.Context, close func(success bool) error, err error) {
+	return ctx, func(bool) error { return nil }, nil
+}
+
+// clone returns a deep copy, so callers can not modify stored values
+func clone[T any](value T) (result T) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	err = json.Unmarshal(b, &result)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"sort"
+)
+
+func (this *Memory) SetOutboxEntry(ctx context.Context, entry model.OutboxEntry) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.outbox[entry.Id] = clone(entry)
+	return nil
+}
+
+func (this *Memory) DeleteOutboxEntry(ctx context.Context, id string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	delete(this.outbox, id)
+	return nil
+}
+
+func (this *Memory) ListOutboxEntries(ctx context.Context, processId string, due int64, limit int64) (result []model.OutboxEntry, err error) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	all := []model.OutboxEntry{}
+	for _, entry := range this.outbox {
+		if entry.Parked || (processId != "" && entry.ProcessId != processId) {
+			continue
+		}
+		if due > 0 && (entry.NextAttempt > due || entry.ClaimedUntil > due) {
+			continue
+		}
+		all = append(all, entry)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Created != all[j].Created {
+			return all[i].Created < all[j].Created
+		}
+		return all[i].Id < all[j].Id
+	})
+	result = []model.OutboxEntry{}
+	for _, entry := range all {
+		if limit > 0 && int64(len(result)) >= limit {
+			break
+		}
+		result = append(result, clone(entry))
+	}
+	return result, nil
+}
+
+func (this *Memory) CountOutboxEntries(ctx context.Context, processId string) (count int64, err error) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	for _, entry := range this.outbox {
+		if entry.ProcessId == processId {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (this *Memory) ClaimOutboxEntry(ctx context.Context, id string, now int64, until int64) (claimed bool, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	entry, ok := this.outbox[id]
+	if !ok || entry.Parked || entry.ClaimedUntil > now {
+		return false, nil
+	}
+	entry.ClaimedUntil = until
+	this.outbox[id] = entry
+	return true, nil
+}
@@ -0,0 +1,286 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+var CleanupLastUpdateTimeBuffer = time.Minute
+
+func (this *Memory) ReadProcess(ctx context.Context, id string) (process model.Process, exists bool, err error) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	stored, exists := this.processes[id]
+	if !exists {
+		return process, false, nil
+	}
+	return clone(stored), true, nil
+}
+
+// project returns a copy of the process with zero values for all fields not listed in fields; nil fields copy all fields
+func project(process model.Process, fields []string) (result model.Process, err error) {
+	if fields == nil {
+		return clone(process), nil
+	}
+	projection, err := model.ProjectProcess(process, append([]string{"_id"}, fields...))
+	if err != nil {
+		return result, err
+	}
+	temp, err := json.Marshal(projection)
+	if err != nil {
+		return result, err
+	}
+	err = json.Unmarshal(temp, &result)
+	return result, err
+}
+
+func (this *Memory) SetProcess(ctx context.Context, process model.Process) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.setProcess(process)
+	return nil
+}
+
+func (this *Memory) setProcess(process model.Process) {
+	if process.LastUpdatedUnix == 0 {
+		process.LastUpdatedUnix = time.Now().Unix()
+	}
+	process.Highlights = nil
+	this.processes[process.Id] = clone(process)
+}
+
+func (this *Memory) SetProcessIfRevision(ctx context.Context, process model.Process, expectedRevision int64) (ok bool, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	stored, exists := this.processes[process.Id]
+	if !exists && expectedRevision != 0 {
+		return false, nil
+	}
+	if exists && stored.Revision != expectedRevision {
+		return false, nil
+	}
+	this.setProcess(process)
+	return true, nil
+}
+
+func (this *Memory) DeleteProcess(ctx context.Context, id string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	delete(this.processes, id)
+	delete(this.revisions, id)
+	return nil
+}
+
+func (this *Memory) ListProcesses(ctx context.Context, listOptions model.ListOptions) (result []model.Process, total int64, err error) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	path, desc := parseSort(listOptions.SortBy)
+	search := strings.TrimSpace(listOptions.Search)
+	sortByScore := path == model.SearchScoreSort
+	if sortByScore {
+		//relevance ties and lists without search are sorted by name
+		path, desc = "name", false
+	}
+	terms := model.SearchTerms(search)
+	filtered := []model.Process{}
+	scores := map[string]float64{}
+	for _, stored := range this.sortedProcesses(path, desc) {
+		if search != "" {
+			scores[stored.Id] = model.SearchScore(stored, terms)
+			if scores[stored.Id] == 0 {
+				continue
+			}
+		}
+		if matchesListOptions(stored, listOptions) {
+			filtered = append(filtered, stored)
+		}
+	}
+	total = int64(len(filtered))
+	if listOptions.WithoutTotal {
+		total = 0
+	}
+	offset := listOptions.Offset
+	if listOptions.After != nil {
+		offset = 0
+		filtered = slices.DeleteFunc(filtered, func(process model.Process) bool {
+			return !isAfterCursor(process, path, desc, *listOptions.After)
+		})
+	}
+	if sortByScore && search != "" {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return scores[filtered[i].Id] > scores[filtered[j].Id]
+		})
+	}
+	for i, process := range filtered {
+		if int64(i) < offset {
+			continue
+		}
+		if listOptions.Limit > 0 && int64(len(result)) >= listOptions.Limit {
+			break
+		}
+		process, err = project(process, listOptions.Fields)
+		if err != nil {
+			return result, total, err
+		}
+		result = append(result, process)
+	}
+	return result, total, nil
+}
+
+func (this *Memory) CountProcessValues(ctx context.Context, field string, listOptions model.ListOptions) (result []model.ValueCount, err error) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	terms := model.SearchTerms(listOptions.Search)
+	search := strings.TrimSpace(listOptions.Search)
+	counts := map[string]int64{}
+	for _, stored := range this.processes {
+		if search != "" && model.SearchScore(stored, terms) == 0 {
+			continue
+		}
+		if !matchesListOptions(stored, listOptions) {
+			continue
+		}
+		value, ok := bsonValue(stored, field)
+		if !ok {
+			continue
+		}
+		switch value.Kind() {
+		case reflect.String:
+			if value.String() != "" {
+				counts[value.String()]++
+			}
+		case reflect.Slice:
+			for i := 0; i < value.Len(); i++ {
+				if value.Index(i).String() != "" {
+					counts[value.Index(i).String()]++
+				}
+			}
+		}
+	}
+	result = []model.ValueCount{}
+	for value, count := range counts {
+		result = append(result, model.ValueCount{Value: value, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Value < result[j].Value
+	})
+	return result, nil
+}
+
+func (this *Memory) CheckIdList(ids []string) (missingInDb []string, missingInInput []string, err error) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	for _, id := range ids {
+		if _, ok := this.processes[id]; !ok {
+			missingInDb = append(missingInDb, id)
+		}
+	}
+	limit := time.Now().Add(-CleanupLastUpdateTimeBuffer).Unix()
+	for _, stored := range this.sortedProcesses("_id", false) {
+		if !slices.Contains(ids, stored.Id) && stored.LastUpdatedUnix < limit && stored.Removal == nil {
+			missingInInput = append(missingInInput, stored.Id)
+		}
+	}
+	return missingInDb, missingInInput, nil
+}
+
+// sortedProcesses returns the stored processes sorted by the bson field path; ties are sorted by id in the same direction
+func (this *Memory) sortedProcesses(path string, desc bool) (result []model.Process) {
+	for _, stored := range this.processes {
+		result = append(result, stored)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		value, ok := bsonValue(result[j], path)
+		return compareSortPosition(result[i], path, desc, value, ok, result[j].Id) < 0
+	})
+	return result
+}
+
+// isAfterCursor checks if the process is sorted behind the cursor position
+func isAfterCursor(process model.Process, path string, desc bool, after model.ListCursor) bool {
+	return compareSortPosition(process, path, desc, reflect.ValueOf(after.Value), after.Value != nil, after.Id) > 0
+}
+
+// compareSortPosition compares the position of process with the position of a (value, id) pair in a list sorted by path
+func compareSortPosition(process model.Process, path string, desc bool, value reflect.Value, ok bool, id string) int {
+	a, aOk := bsonValue(process, path)
+	c := compareBsonValues(a, aOk, value, ok)
+	if c == 0 {
+		c = strings.Compare(process.Id, id)
+	}
+	if desc {
+		c = -c
+	}
+	return c
+}
+
+func matchesListOptions(process model.Process, listOptions model.ListOptions) bool {
+	if listOptions.Ids != nil && !slices.Contains(listOptions.Ids, process.Id) {
+		return false
+	}
+	if listOptions.Public && !process.Publish {
+		return false
+	}
+	if listOptions.Removed != (process.Removal != nil) {
+		return false
+	}
+	if listOptions.Category != "" && !slices.Contains(process.Categories, listOptions.Category) {
+		return false
+	}
+	if listOptions.Tag != "" && !slices.Contains(process.Tags, listOptions.Tag) {
+		return false
+	}
+	if listOptions.Folder != "" && !model.InFolder(process.Folder, listOptions.Folder) {
+		return false
+	}
+	metadata := process.Metadata
+	for _, filter := range []struct {
+		value  string
+		values []string
+	}{
+		{listOptions.Topic, metadata.Topics},
+		{listOptions.Message, metadata.Messages},
+		{listOptions.Signal, metadata.Signals},
+		{listOptions.Lane, metadata.Lanes},
+		{listOptions.InputParameter, metadata.InputParameters},
+		{listOptions.OutputParameter, metadata.OutputParameters},
+	} {
+		if filter.value != "" && !slices.Contains(filter.values, filter.value) {
+			return false
+		}
+	}
+	if listOptions.TimerType != "" && !slices.ContainsFunc(metadata.Timers, func(timer model.TimerDefinition) bool {
+		return timer.Type == listOptions.TimerType
+	}) {
+		return false
+	}
+	if listOptions.MinTaskCount > 0 && metadata.TaskCount < listOptions.MinTaskCount {
+		return false
+	}
+	if listOptions.MaxTaskCount > 0 && metadata.TaskCount > listOptions.MaxTaskCount {
+		return false
+	}
+	return true
+}
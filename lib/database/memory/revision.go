@@ -0,0 +1,69 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"sort"
+)
+
+func (this *Memory) SetProcessRevision(ctx context.Context, revision model.ProcessRevision) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.revisions[revision.ProcessId] == nil {
+		this.revisions[revision.ProcessId] = map[int64]model.ProcessRevision{}
+	}
+	this.revisions[revision.ProcessId][revision.Revision] = clone(revision)
+	return nil
+}
+
+func (this *Memory) ReadProcessRevision(ctx context.Context, processId string, revision int64) (result model.ProcessRevision, exists bool, err error) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	result, exists = this.revisions[processId][revision]
+	if !exists {
+		return result, false, nil
+	}
+	return clone(result), true, nil
+}
+
+func (this *Memory) ListProcessRevisions(ctx context.Context, processId string, limit int64, offset int64) (result []model.ProcessRevision, total int64, err error) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	all := []model.ProcessRevision{}
+	for _, revision := range this.revisions[processId] {
+		all = append(all, revision)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Revision > all[j].Revision
+	})
+	result = []model.ProcessRevision{}
+	for i, revision := range all {
+		if int64(i) < offset {
+			continue
+		}
+		if limit > 0 && int64(len(result)) >= limit {
+			break
+		}
+		revision = clone(revision)
+		revision.Process.BpmnXml = ""
+		revision.Process.SvgXml = ""
+		result = append(result, revision)
+	}
+	return result, int64(len(all)), nil
+}
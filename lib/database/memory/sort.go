@@ -0,0 +1,120 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"reflect"
+	"strings"
+)
+
+// parseSort splits a sort parameter like "name.desc" into bson field path and direction
+func parseSort(sortBy string) (path string, desc bool) {
+	if sortBy == "" {
+		sortBy = "name.asc"
+	}
+	desc = strings.HasSuffix(sortBy, ".desc")
+	path = strings.TrimSuffix(sortBy, ".asc")
+	path = strings.TrimSuffix(path, ".desc")
+	return path, desc
+}
+
+// bsonValue returns the value of the field addressed by a dot separated path of bson field names.
+// ok is false if the path does not exist, which mongodb handles like null.
+func bsonValue(obj interface{}, path string) (result reflect.Value, ok bool) {
+	result = reflect.ValueOf(obj)
+	for _, name := range strings.Split(path, ".") {
+		for result.Kind() == reflect.Pointer {
+			if result.IsNil() {
+				return result, false
+			}
+			result = result.Elem()
+		}
+		if result.Kind() != reflect.Struct {
+			return result, false
+		}
+		found := false
+		for i := 0; i < result.NumField(); i++ {
+			tag := strings.Split(result.Type().Field(i).Tag.Get("bson"), ",")[0]
+			if tag == name {
+				result = result.Field(i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return result, false
+		}
+	}
+	return result, true
+}
+
+// compareBsonValues orders values like mongodb for the types used in the models: null < numbers < strings < booleans
+func compareBsonValues(a reflect.Value, aOk bool, b reflect.Value, bOk bool) int {
+	rankA, rankB := typeRank(a, aOk), typeRank(b, bOk)
+	if rankA != rankB {
+		return rankA - rankB
+	}
+	switch rankA {
+	case 1:
+		return compareOrdered(toFloat(a), toFloat(b))
+	case 2:
+		return strings.Compare(a.String(), b.String())
+	case 3:
+		return compareOrdered(boolToInt(a.Bool()), boolToInt(b.Bool()))
+	}
+	return 0
+}
+
+func typeRank(value reflect.Value, ok bool) int {
+	if !ok {
+		return 0
+	}
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		return 1
+	case reflect.String:
+		return 2
+	case reflect.Bool:
+		return 3
+	}
+	return 0
+}
+
+func toFloat(value reflect.Value) float64 {
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return value.Float()
+	}
+	return float64(value.Int())
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func compareOrdered[T int | float64](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
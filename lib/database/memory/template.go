@@ -0,0 +1,93 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"sort"
+	"strings"
+	"time"
+)
+
+func (this *Memory) ReadTemplate(ctx context.Context, id string) (result model.ProcessTemplate, exists bool, err error) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	result, exists = this.templates[id]
+	if !exists {
+		return result, false, nil
+	}
+	return clone(result), true, nil
+}
+
+func (this *Memory) SetTemplate(ctx context.Context, template model.ProcessTemplate) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if template.LastUpdatedUnix == 0 {
+		template.LastUpdatedUnix = time.Now().Unix()
+	}
+	this.templates[template.Id] = clone(template)
+	return nil
+}
+
+func (this *Memory) DeleteTemplate(ctx context.Context, id string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	delete(this.templates, id)
+	return nil
+}
+
+func (this *Memory) ListTemplates(ctx context.Context, listOptions model.TemplateListOptions) (result []model.ProcessTemplate, total int64, err error) {
+	this.mux.RLock()
+	defer this.mux.RUnlock()
+	path, desc := parseSort(listOptions.SortBy)
+	search := strings.ToLower(strings.TrimSpace(listOptions.Search))
+	filtered := []model.ProcessTemplate{}
+	for _, template := range this.templates {
+		if listOptions.Owner != "" && template.Owner != listOptions.Owner && !(listOptions.IncludePublished && template.Publish) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(template.Name), search) && !strings.Contains(strings.ToLower(template.Description), search) {
+			continue
+		}
+		filtered = append(filtered, template)
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		a, aOk := bsonValue(filtered[i], path)
+		b, bOk := bsonValue(filtered[j], path)
+		c := compareBsonValues(a, aOk, b, bOk)
+		if desc {
+			c = -c
+		}
+		if c != 0 {
+			return c < 0
+		}
+		return filtered[i].Id < filtered[j].Id
+	})
+	total = int64(len(filtered))
+	result = []model.ProcessTemplate{}
+	for i, template := range filtered {
+		if int64(i) < listOptions.Offset {
+			continue
+		}
+		if listOptions.Limit > 0 && int64(len(result)) >= listOptions.Limit {
+			break
+		}
+		result = append(result, clone(template))
+	}
+	return result, total, nil
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mongo
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+)
+
+var auditIdKey string
+var auditProcessIdKey string
+var auditDateKey string
+
+func init() {
+	var err error
+	auditIdKey, err = getBsonFieldName(model.AuditRecord{}, "Id")
+	if err != nil {
+		log.Fatal(err)
+	}
+	auditProcessIdKey, err = getBsonFieldName(model.AuditRecord{}, "ProcessId")
+	if err != nil {
+		log.Fatal(err)
+	}
+	auditDateKey, err = getBsonFieldName(model.AuditRecord{}, "Date")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	CreateCollections = append(CreateCollections, func(db *Mongo) error {
+		collection := db.client.Database(db.config.MongoTable).Collection(db.config.MongoAuditCollection)
+		err = db.ensureCompoundIndex(collection, "auditprocessdateindex", true, false, auditProcessIdKey, auditDateKey)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func (this *Mongo) AuditCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoAuditCollection)
+}
+
+func (this *Mongo) SetAuditRecord(ctx context.Context, record model.AuditRecord) error {
+	_, err := this.AuditCollection().ReplaceOne(ctx, bson.M{auditIdKey: record.Id}, record, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (this *Mongo) ListAuditRecords(ctx context.Context, processId string, limit int64, offset int64) (result []model.AuditRecord, total int64, err error) {
+	opt := options.Find().SetSort(bson.D{{Key: auditDateKey, Value: -1}, {Key: auditIdKey, Value: -1}})
+	if limit > 0 {
+		opt.SetLimit(limit)
+	}
+	if offset > 0 {
+		opt.SetSkip(offset)
+	}
+	filter := bson.M{auditProcessIdKey: processId}
+	cursor, err := this.AuditCollection().Find(ctx, filter, opt)
+	if err != nil {
+		return result, total, err
+	}
+	result = []model.AuditRecord{}
+	err = cursor.All(ctx, &result)
+	if err != nil {
+		return result, total, err
+	}
+	total, err = this.AuditCollection().CountDocuments(ctx, filter)
+	return result, total, err
+}
@@ -130,6 +130,20 @@ func (this *Mongo) ensureCompoundIndex(collection *mongo.Collection, indexname s
 	return err
 }
 
+// ensureTextIndex creates a text index over the weighted keys; the language "none" disables stop words and stemming
+func (this *Mongo) ensureTextIndex(collection *mongo.Collection, indexname string, weightedKeys bson.D) error {
+	ctx, _ := getTimeoutContext(context.Background())
+	keys := bson.D{}
+	for _, key := range weightedKeys {
+		keys = append(keys, bson.E{Key: key.Key, Value: "text"})
+	}
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    keys,
+		Options: options.Index().SetName(indexname).SetWeights(weightedKeys).SetDefaultLanguage("none"),
+	})
+	return err
+}
+
 func (this *Mongo) Disconnect() {
 	log.Println(this.client.Disconnect(context.Background()))
 }
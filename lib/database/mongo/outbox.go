@@ -0,0 +1,126 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+)
+
+var outboxIdKey string
+var outboxProcessIdKey string
+var outboxCreatedKey string
+var outboxNextAttemptKey string
+var outboxClaimedUntilKey string
+var outboxParkedKey string
+
+func init() {
+	var err error
+	outboxIdKey, err = getBsonFieldName(model.OutboxEntry{}, "Id")
+	if err != nil {
+		log.Fatal(err)
+	}
+	outboxProcessIdKey, err = getBsonFieldName(model.OutboxEntry{}, "ProcessId")
+	if err != nil {
+		log.Fatal(err)
+	}
+	outboxCreatedKey, err = getBsonFieldName(model.OutboxEntry{}, "Created")
+	if err != nil {
+		log.Fatal(err)
+	}
+	outboxNextAttemptKey, err = getBsonFieldName(model.OutboxEntry{}, "NextAttempt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	outboxClaimedUntilKey, err = getBsonFieldName(model.OutboxEntry{}, "ClaimedUntil")
+	if err != nil {
+		log.Fatal(err)
+	}
+	outboxParkedKey, err = getBsonFieldName(model.OutboxEntry{}, "Parked")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	CreateCollections = append(CreateCollections, func(db *Mongo) error {
+		collection := db.client.Database(db.config.MongoTable).Collection(db.config.MongoOutboxCollection)
+		err = db.ensureIndex(collection, "outboxcreatedindex", outboxCreatedKey, true, false)
+		if err != nil {
+			return err
+		}
+		err = db.ensureCompoundIndex(collection, "outboxprocesscreatedindex", true, false, outboxProcessIdKey, outboxCreatedKey)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func (this *Mongo) OutboxCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoOutboxCollection)
+}
+
+func (this *Mongo) SetOutboxEntry(ctx context.Context, entry model.OutboxEntry) error {
+	_, err := this.OutboxCollection().ReplaceOne(ctx, bson.M{outboxIdKey: entry.Id}, entry, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (this *Mongo) DeleteOutboxEntry(ctx context.Context, id string) error {
+	_, err := this.OutboxCollection().DeleteOne(ctx, bson.M{outboxIdKey: id})
+	return err
+}
+
+func (this *Mongo) ListOutboxEntries(ctx context.Context, processId string, due int64, limit int64) (result []model.OutboxEntry, err error) {
+	filter := bson.M{outboxParkedKey: bson.M{"$ne": true}}
+	if processId != "" {
+		filter[outboxProcessIdKey] = processId
+	}
+	if due > 0 {
+		filter[outboxNextAttemptKey] = bson.M{"$lte": due}
+		filter[outboxClaimedUntilKey] = bson.M{"$not": bson.M{"$gt": due}}
+	}
+	opt := options.Find().SetSort(bson.D{{Key: outboxCreatedKey, Value: 1}, {Key: outboxIdKey, Value: 1}})
+	if limit > 0 {
+		opt.SetLimit(limit)
+	}
+	cursor, err := this.OutboxCollection().Find(ctx, filter, opt)
+	if err != nil {
+		return result, err
+	}
+	result = []model.OutboxEntry{}
+	err = cursor.All(ctx, &result)
+	return result, err
+}
+
+func (this *Mongo) ClaimOutboxEntry(ctx context.Context, id string, now int64, until int64) (claimed bool, err error) {
+	result, err := this.OutboxCollection().UpdateOne(ctx, bson.M{
+		outboxIdKey:           id,
+		outboxParkedKey:       bson.M{"$ne": true},
+		outboxClaimedUntilKey: bson.M{"$not": bson.M{"$gt": now}},
+	}, bson.M{"$set": bson.M{outboxClaimedUntilKey: until}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount == 1, nil
+}
+
+func (this *Mongo) CountOutboxEntries(ctx context.Context, processId string) (count int64, err error) {
+	return this.OutboxCollection().CountDocuments(ctx, bson.M{outboxProcessIdKey: processId})
+}
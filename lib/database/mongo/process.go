@@ -21,6 +21,7 @@ import (
 	"errors"
 	"github.com/SENERGY-Platform/process-model-repository/lib/model"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"log"
@@ -31,9 +32,30 @@ import (
 
 const processIdFieldName = "Id"
 const processPublicFieldName = "Publish"
+const processRevisionFieldName = "Revision"
+const processMetadataFieldName = "Metadata"
+const processCategoriesFieldName = "Categories"
+const processTagsFieldName = "Tags"
+const processFolderFieldName = "Folder"
+const processRemovalFieldName = "Removal"
 
 var processIdKey string
 var processPublicKey string
+var processRevisionKey string
+var processMetadataKey string
+var processCategoriesKey string
+var processTagsKey string
+var processFolderKey string
+var processRemovalKey string
+var metadataTopicsKey string
+var metadataMessagesKey string
+var metadataSignalsKey string
+var metadataLanesKey string
+var metadataInputParametersKey string
+var metadataOutputParametersKey string
+var metadataTimerTypeKey string
+var metadataTaskCountKey string
+var metadataTextsKey string
 
 func init() {
 	var err error
@@ -45,6 +67,50 @@ func init() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	processRevisionKey, err = getBsonFieldName(model.Process{}, processRevisionFieldName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	processMetadataKey, err = getBsonFieldName(model.Process{}, processMetadataFieldName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	processCategoriesKey, err = getBsonFieldName(model.Process{}, processCategoriesFieldName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	processTagsKey, err = getBsonFieldName(model.Process{}, processTagsFieldName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	processFolderKey, err = getBsonFieldName(model.Process{}, processFolderFieldName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	processRemovalKey, err = getBsonFieldName(model.Process{}, processRemovalFieldName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	metadataKey := func(fieldName string) string {
+		key, err := getBsonFieldName(model.ProcessMetadata{}, fieldName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return processMetadataKey + "." + key
+	}
+	metadataTopicsKey = metadataKey("Topics")
+	metadataMessagesKey = metadataKey("Messages")
+	metadataSignalsKey = metadataKey("Signals")
+	metadataLanesKey = metadataKey("Lanes")
+	metadataInputParametersKey = metadataKey("InputParameters")
+	metadataOutputParametersKey = metadataKey("OutputParameters")
+	metadataTaskCountKey = metadataKey("TaskCount")
+	metadataTextsKey = metadataKey("Texts")
+	timerTypeKey, err := getBsonFieldName(model.TimerDefinition{}, "Type")
+	if err != nil {
+		log.Fatal(err)
+	}
+	metadataTimerTypeKey = metadataKey("Timers") + "." + timerTypeKey
 
 	CreateCollections = append(CreateCollections, func(db *Mongo) error {
 		collection := db.client.Database(db.config.MongoTable).Collection(db.config.MongoProcessCollection)
@@ -56,6 +122,46 @@ func init() {
 		if err != nil {
 			return err
 		}
+		err = db.ensureIndex(collection, "processtopicsindex", metadataTopicsKey, true, false)
+		if err != nil {
+			return err
+		}
+		err = db.ensureIndex(collection, "processmessagesindex", metadataMessagesKey, true, false)
+		if err != nil {
+			return err
+		}
+		err = db.ensureIndex(collection, "processsignalsindex", metadataSignalsKey, true, false)
+		if err != nil {
+			return err
+		}
+		err = db.ensureIndex(collection, "processcategoriesindex", processCategoriesKey, true, false)
+		if err != nil {
+			return err
+		}
+		err = db.ensureIndex(collection, "processtagsindex", processTagsKey, true, false)
+		if err != nil {
+			return err
+		}
+		err = db.ensureIndex(collection, "processfolderindex", processFolderKey, true, false)
+		if err != nil {
+			return err
+		}
+		err = db.ensureIndex(collection, "processremovalindex", processRemovalKey, true, false)
+		if err != nil {
+			return err
+		}
+		err = db.ensureCompoundIndex(collection, "processnameidindex", true, false, "name", processIdKey)
+		if err != nil {
+			return err
+		}
+		err = db.ensureTextIndex(collection, "processtextindex", bson.D{
+			{Key: "name", Value: model.SearchWeightName},
+			{Key: "description", Value: model.SearchWeightDescription},
+			{Key: metadataTextsKey, Value: model.SearchWeightTexts},
+		})
+		if err != nil {
+			return err
+		}
 		return nil
 	})
 }
@@ -80,15 +186,6 @@ func (this *Mongo) ReadProcess(ctx context.Context, id string) (process model.Pr
 	return process, true, err
 }
 
-func (this *Mongo) ReadAllPublicProcesses(ctx context.Context) (processes []model.Process, err error) {
-	cursor, err := this.ProcessCollection().Find(ctx, bson.M{processPublicKey: true})
-	if err != nil {
-		return nil, err
-	}
-	err = cursor.All(ctx, &processes)
-	return
-}
-
 func (this *Mongo) SetProcess(ctx context.Context, process model.Process) error {
 	if process.LastUpdatedUnix == 0 {
 		process.LastUpdatedUnix = time.Now().Unix()
@@ -97,9 +194,38 @@ func (this *Mongo) SetProcess(ctx context.Context, process model.Process) error
 	return err
 }
 
+// SetProcessIfRevision replaces the process only if the stored revision equals expectedRevision.
+// the check is part of the replace filter, so concurrent writes of the same revision can not both succeed.
+// legacy documents without revision are handled as revision 0.
+func (this *Mongo) SetProcessIfRevision(ctx context.Context, process model.Process, expectedRevision int64) (ok bool, err error) {
+	if process.LastUpdatedUnix == 0 {
+		process.LastUpdatedUnix = time.Now().Unix()
+	}
+	if expectedRevision == 0 {
+		filter := bson.M{processIdKey: process.Id, processRevisionKey: bson.M{"$in": []interface{}{0, nil}}}
+		_, err = this.ProcessCollection().ReplaceOne(ctx, filter, process, options.Replace().SetUpsert(true))
+		if mongo.IsDuplicateKeyError(err) {
+			//upsert of an existing id -> stored revision differs
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	result, err := this.ProcessCollection().ReplaceOne(ctx, bson.M{processIdKey: process.Id, processRevisionKey: expectedRevision}, process)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
 func (this *Mongo) DeleteProcess(ctx context.Context, id string) error {
 	_, err := this.ProcessCollection().DeleteMany(ctx, bson.M{processIdKey: id})
-	return err
+	if err != nil {
+		return err
+	}
+	return this.deleteProcessRevisions(ctx, id)
 }
 
 func (this *Mongo) ListProcesses(ctx context.Context, listOptions model.ListOptions) (result []model.Process, total int64, err error) {
@@ -107,7 +233,7 @@ func (this *Mongo) ListProcesses(ctx context.Context, listOptions model.ListOpti
 	if listOptions.Limit > 0 {
 		opt.SetLimit(listOptions.Limit)
 	}
-	if listOptions.Offset > 0 {
+	if listOptions.Offset > 0 && listOptions.After == nil {
 		opt.SetSkip(listOptions.Offset)
 	}
 
@@ -123,22 +249,39 @@ func (this *Mongo) ListProcesses(ctx context.Context, listOptions model.ListOpti
 	if strings.HasSuffix(listOptions.SortBy, ".desc") {
 		direction = int32(-1)
 	}
-	opt.SetSort(bson.D{{sortby, direction}})
 
-	filter := bson.M{}
-	if listOptions.Ids != nil {
-		filter["_id"] = bson.M{"$in": listOptions.Ids}
+	projection := bson.M{}
+	if listOptions.Fields != nil {
+		projection = fieldProjection(listOptions.Fields)
 	}
 	search := strings.TrimSpace(listOptions.Search)
-	if search != "" {
-		escapedSearch := regexp.QuoteMeta(search)
-		filter["$or"] = []interface{}{
-			bson.M{"name": bson.M{"$regex": escapedSearch, "$options": "i"}},
-			bson.M{"description": bson.M{"$regex": escapedSearch, "$options": "i"}},
-		}
+	switch {
+	case sortby == model.SearchScoreSort && search != "":
+		projection["score"] = bson.M{"$meta": "textScore"}
+		opt.SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "name", Value: 1}, {Key: processIdKey, Value: 1}})
+	case sortby == model.SearchScoreSort:
+		opt.SetSort(bson.D{{Key: "name", Value: 1}, {Key: processIdKey, Value: 1}})
+	case sortby == processIdKey:
+		opt.SetSort(bson.D{{sortby, direction}})
+	default:
+		//_id as tiebreaker makes the order stable, which is required for continuation tokens
+		opt.SetSort(bson.D{{Key: sortby, Value: direction}, {Key: processIdKey, Value: direction}})
+	}
+
+	if len(projection) > 0 {
+		opt.SetProjection(projection)
 	}
 
-	cursor, err := this.ProcessCollection().Find(ctx, filter, opt)
+	filter, matchesNothing := processListFilter(listOptions)
+	if matchesNothing {
+		return result, total, nil
+	}
+
+	findFilter := filter
+	if listOptions.After != nil {
+		findFilter = bson.M{"$and": []interface{}{filter, afterCursorFilter(sortby, direction, *listOptions.After)}}
+	}
+	cursor, err := this.ProcessCollection().Find(ctx, findFilter, opt)
 	if err != nil {
 		return result, total, err
 	}
@@ -146,6 +289,9 @@ func (this *Mongo) ListProcesses(ctx context.Context, listOptions model.ListOpti
 	if err != nil {
 		return result, total, err
 	}
+	if listOptions.WithoutTotal {
+		return result, 0, nil
+	}
 	total, err = this.ProcessCollection().CountDocuments(ctx, filter)
 	if err != nil {
 		return result, total, err
@@ -153,6 +299,143 @@ func (this *Mongo) ListProcesses(ctx context.Context, listOptions model.ListOpti
 	return result, total, err
 }
 
+func (this *Mongo) CountProcessValues(ctx context.Context, field string, listOptions model.ListOptions) (result []model.ValueCount, err error) {
+	result = []model.ValueCount{}
+	filter, matchesNothing := processListFilter(listOptions)
+	if matchesNothing {
+		return result, nil
+	}
+	cursor, err := this.ProcessCollection().Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$unwind", Value: "$" + field}},
+		{{Key: "$match", Value: bson.M{field: bson.M{"$type": "string", "$ne": ""}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$" + field, "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	})
+	if err != nil {
+		return result, err
+	}
+	counts := []struct {
+		Value string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}{}
+	err = cursor.All(ctx, &counts)
+	if err != nil {
+		return result, err
+	}
+	for _, count := range counts {
+		result = append(result, model.ValueCount{Value: count.Value, Count: count.Count})
+	}
+	return result, nil
+}
+
+// processListFilter returns the filter of the list options; matchesNothing is true for searches without words
+func processListFilter(listOptions model.ListOptions) (filter bson.M, matchesNothing bool) {
+	filter = bson.M{}
+	if listOptions.Ids != nil {
+		filter["_id"] = bson.M{"$in": listOptions.Ids}
+	}
+	if listOptions.Public {
+		filter[processPublicKey] = true
+	}
+	if listOptions.Removed {
+		filter[processRemovalKey] = bson.M{"$type": "object"}
+	} else {
+		filter[processRemovalKey] = nil
+	}
+	if listOptions.Category != "" {
+		filter[processCategoriesKey] = listOptions.Category
+	}
+	if listOptions.Tag != "" {
+		filter[processTagsKey] = listOptions.Tag
+	}
+	if listOptions.Folder != "" {
+		filter[processFolderKey] = bson.M{"$in": []interface{}{
+			listOptions.Folder,
+			primitive.Regex{Pattern: "^" + regexp.QuoteMeta(listOptions.Folder+"/")},
+		}}
+	}
+	search := strings.TrimSpace(listOptions.Search)
+	if search != "" {
+		terms := model.SearchTerms(search)
+		if len(terms) == 0 {
+			return filter, true
+		}
+		//the text index finds candidates containing any term and provides the score;
+		//the word patterns restrict the result to processes containing every term
+		filter["$text"] = bson.M{"$search": strings.Join(terms, " ")}
+		termFilters := []interface{}{}
+		for _, term := range terms {
+			pattern := `(^|[^\p{L}\p{N}])` + regexp.QuoteMeta(term) + `($|[^\p{L}\p{N}])`
+			termFilters = append(termFilters, bson.M{"$or": []interface{}{
+				bson.M{"name": bson.M{"$regex": pattern, "$options": "i"}},
+				bson.M{"description": bson.M{"$regex": pattern, "$options": "i"}},
+				bson.M{metadataTextsKey: bson.M{"$regex": pattern, "$options": "i"}},
+			}})
+		}
+		filter["$and"] = termFilters
+	}
+	for key, value := range map[string]string{
+		metadataTopicsKey:           listOptions.Topic,
+		metadataMessagesKey:         listOptions.Message,
+		metadataSignalsKey:          listOptions.Signal,
+		metadataLanesKey:            listOptions.Lane,
+		metadataInputParametersKey:  listOptions.InputParameter,
+		metadataOutputParametersKey: listOptions.OutputParameter,
+		metadataTimerTypeKey:        listOptions.TimerType,
+	} {
+		if value != "" {
+			filter[key] = value
+		}
+	}
+	taskCountFilter := bson.M{}
+	if listOptions.MinTaskCount > 0 {
+		taskCountFilter["$gte"] = listOptions.MinTaskCount
+	}
+	if listOptions.MaxTaskCount > 0 {
+		taskCountFilter["$lte"] = listOptions.MaxTaskCount
+	}
+	if len(taskCountFilter) > 0 {
+		filter[metadataTaskCountKey] = taskCountFilter
+	}
+	return filter, false
+}
+
+func fieldProjection(fields []string) bson.M {
+	result := bson.M{processIdKey: 1}
+	for _, field := range fields {
+		result[field] = 1
+	}
+	return result
+}
+
+// afterCursorFilter matches the documents sorted behind the cursor by (key, _id).
+// missing values are sorted like null, before all other values.
+func afterCursorFilter(key string, direction int32, after model.ListCursor) bson.M {
+	op := "$gt"
+	if direction < 0 {
+		op = "$lt"
+	}
+	if key == processIdKey {
+		return bson.M{processIdKey: bson.M{op: after.Id}}
+	}
+	if after.Value == nil {
+		sameValue := bson.M{key: nil, processIdKey: bson.M{op: after.Id}}
+		if direction < 0 {
+			return sameValue
+		}
+		return bson.M{"$or": []interface{}{sameValue, bson.M{key: bson.M{"$ne": nil}}}}
+	}
+	alternatives := []interface{}{
+		bson.M{key: bson.M{op: after.Value}},
+		bson.M{key: after.Value, processIdKey: bson.M{op: after.Id}},
+	}
+	if direction < 0 {
+		alternatives = append(alternatives, bson.M{key: nil})
+	}
+	return bson.M{"$or": alternatives}
+}
+
 var CleanupLastUpdateTimeBuffer = time.Minute
 
 func (this *Mongo) CheckIdList(ids []string) (missingInDb []string, missingInInput []string, err error) {
@@ -175,7 +458,8 @@ func (this *Mongo) CheckIdList(ids []string) (missingInDb []string, missingInInp
 	}
 
 	missingInInputList, err := this.ProcessCollection().Distinct(context.Background(), processIdKey, bson.M{
-		processIdKey: bson.M{"$nin": ids},
+		processIdKey:      bson.M{"$nin": ids},
+		processRemovalKey: nil,
 		"$or": []interface{}{
 			bson.M{"last_updated_unix": bson.M{"$exists": false}},
 			bson.M{"last_updated_unix": bson.M{"$lt": time.Now().Add(-CleanupLastUpdateTimeBuffer).Unix()}},
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+)
+
+const revisionProcessIdFieldName = "ProcessId"
+const revisionRevisionFieldName = "Revision"
+
+var revisionProcessIdKey string
+var revisionRevisionKey string
+var revisionBpmnKey = "process.bpmn_xml"
+var revisionSvgKey = "process.svgXML"
+
+func init() {
+	var err error
+	revisionProcessIdKey, err = getBsonFieldName(model.ProcessRevision{}, revisionProcessIdFieldName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	revisionRevisionKey, err = getBsonFieldName(model.ProcessRevision{}, revisionRevisionFieldName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	CreateCollections = append(CreateCollections, func(db *Mongo) error {
+		collection := db.client.Database(db.config.MongoTable).Collection(db.config.MongoRevisionCollection)
+		err = db.ensureCompoundIndex(collection, "processrevisionindex", true, true, revisionProcessIdKey, revisionRevisionKey)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func (this *Mongo) RevisionCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoRevisionCollection)
+}
+
+func (this *Mongo) SetProcessRevision(ctx context.Context, revision model.ProcessRevision) error {
+	_, err := this.RevisionCollection().ReplaceOne(ctx, bson.M{revisionProcessIdKey: revision.ProcessId, revisionRevisionKey: revision.Revision}, revision, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (this *Mongo) ReadProcessRevision(ctx context.Context, processId string, revision int64) (result model.ProcessRevision, exists bool, err error) {
+	err = this.RevisionCollection().FindOne(ctx, bson.M{revisionProcessIdKey: processId, revisionRevisionKey: revision}).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return result, false, nil
+	}
+	if err != nil {
+		return result, false, err
+	}
+	return result, true, nil
+}
+
+func (this *Mongo) ListProcessRevisions(ctx context.Context, processId string, limit int64, offset int64) (result []model.ProcessRevision, total int64, err error) {
+	opt := options.Find().
+		SetSort(bson.D{{Key: revisionRevisionKey, Value: -1}}).
+		SetProjection(bson.M{revisionBpmnKey: 0, revisionSvgKey: 0})
+	if limit > 0 {
+		opt.SetLimit(limit)
+	}
+	if offset > 0 {
+		opt.SetSkip(offset)
+	}
+	filter := bson.M{revisionProcessIdKey: processId}
+	cursor, err := this.RevisionCollection().Find(ctx, filter, opt)
+	if err != nil {
+		return result, total, err
+	}
+	result = []model.ProcessRevision{}
+	err = cursor.All(ctx, &result)
+	if err != nil {
+		return result, total, err
+	}
+	total, err = this.RevisionCollection().CountDocuments(ctx, filter)
+	return result, total, err
+}
+
+func (this *Mongo) deleteProcessRevisions(ctx context.Context, processId string) error {
+	_, err := this.RevisionCollection().DeleteMany(ctx, bson.M{revisionProcessIdKey: processId})
+	return err
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const templateIdFieldName = "Id"
+const templateOwnerFieldName = "Owner"
+const templatePublishFieldName = "Publish"
+
+var templateIdKey string
+var templateOwnerKey string
+var templatePublishKey string
+
+func init() {
+	var err error
+	templateIdKey, err = getBsonFieldName(model.ProcessTemplate{}, templateIdFieldName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	templateOwnerKey, err = getBsonFieldName(model.ProcessTemplate{}, templateOwnerFieldName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	templatePublishKey, err = getBsonFieldName(model.ProcessTemplate{}, templatePublishFieldName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	CreateCollections = append(CreateCollections, func(db *Mongo) error {
+		collection := db.client.Database(db.config.MongoTable).Collection(db.config.MongoTemplateCollection)
+		err = db.ensureIndex(collection, "templateownerindex", templateOwnerKey, true, false)
+		if err != nil {
+			return err
+		}
+		err = db.ensureIndex(collection, "templatepublishindex", templatePublishKey, true, false)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func (this *Mongo) TemplateCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoTemplateCollection)
+}
+
+func (this *Mongo) ReadTemplate(ctx context.Context, id string) (result model.ProcessTemplate, exists bool, err error) {
+	err = this.TemplateCollection().FindOne(ctx, bson.M{templateIdKey: id}).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return result, false, nil
+	}
+	if err != nil {
+		return result, false, err
+	}
+	return result, true, nil
+}
+
+func (this *Mongo) SetTemplate(ctx context.Context, template model.ProcessTemplate) error {
+	if template.LastUpdatedUnix == 0 {
+		template.LastUpdatedUnix = time.Now().Unix()
+	}
+	_, err := this.TemplateCollection().ReplaceOne(ctx, bson.M{templateIdKey: template.Id}, template, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (this *Mongo) DeleteTemplate(ctx context.Context, id string) error {
+	_, err := this.TemplateCollection().DeleteMany(ctx, bson.M{templateIdKey: id})
+	return err
+}
+
+func (this *Mongo) ListTemplates(ctx context.Context, listOptions model.TemplateListOptions) (result []model.ProcessTemplate, total int64, err error) {
+	opt := options.Find()
+	if listOptions.Limit > 0 {
+		opt.SetLimit(listOptions.Limit)
+	}
+	if listOptions.Offset > 0 {
+		opt.SetSkip(listOptions.Offset)
+	}
+	if listOptions.SortBy == "" {
+		listOptions.SortBy = "name.asc"
+	}
+	sortby := listOptions.SortBy
+	sortby = strings.TrimSuffix(sortby, ".asc")
+	sortby = strings.TrimSuffix(sortby, ".desc")
+	direction := int32(1)
+	if strings.HasSuffix(listOptions.SortBy, ".desc") {
+		direction = int32(-1)
+	}
+	opt.SetSort(bson.D{{Key: sortby, Value: direction}, {Key: templateIdKey, Value: 1}})
+
+	filter := bson.M{}
+	if listOptions.Owner != "" {
+		if listOptions.IncludePublished {
+			filter["$or"] = []interface{}{
+				bson.M{templateOwnerKey: listOptions.Owner},
+				bson.M{templatePublishKey: true},
+			}
+		} else {
+			filter[templateOwnerKey] = listOptions.Owner
+		}
+	}
+	search := strings.TrimSpace(listOptions.Search)
+	if search != "" {
+		escapedSearch := regexp.QuoteMeta(search)
+		searchFilter := bson.M{"$or": []interface{}{
+			bson.M{"name": bson.M{"$regex": escapedSearch, "$options": "i"}},
+			bson.M{"description": bson.M{"$regex": escapedSearch, "$options": "i"}},
+		}}
+		filter = bson.M{"$and": []interface{}{filter, searchFilter}}
+	}
+
+	cursor, err := this.TemplateCollection().Find(ctx, filter, opt)
+	if err != nil {
+		return result, total, err
+	}
+	result = []model.ProcessTemplate{}
+	err = cursor.All(ctx, &result)
+	if err != nil {
+		return result, total, err
+	}
+	total, err = this.TemplateCollection().CountDocuments(ctx, filter)
+	return result, total, err
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+)
+
+func (this *Postgres) SetAuditRecord(ctx context.Context, record model.AuditRecord) error {
+	document, err := marshalDocument(record)
+	if err != nil {
+		return err
+	}
+	_, err = this.db(ctx).Exec(ctx, `INSERT INTO process_audit (id, process_id, date, document) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET process_id = EXCLUDED.process_id, date = EXCLUDED.date, document = EXCLUDED.document`,
+		record.Id, record.ProcessId, record.Date, document)
+	return err
+}
+
+func (this *Postgres) ListAuditRecords(ctx context.Context, processId string, limit int64, offset int64) (result []model.AuditRecord, total int64, err error) {
+	err = this.db(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM process_audit WHERE process_id = $1`, processId).Scan(&total)
+	if err != nil {
+		return result, total, err
+	}
+	query := `SELECT document FROM process_audit WHERE process_id = $1 ORDER BY date DESC, id COLLATE "C" DESC OFFSET $2`
+	args := []interface{}{processId, offset}
+	if limit > 0 {
+		query = query + ` LIMIT $3`
+		args = append(args, limit)
+	}
+	rows, err := this.db(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return result, total, err
+	}
+	defer rows.Close()
+	result = []model.AuditRecord{}
+	for rows.Next() {
+		var document []byte
+		err = rows.Scan(&document)
+		if err != nil {
+			return result, total, err
+		}
+		record := model.AuditRecord{}
+		err = json.Unmarshal(document, &record)
+		if err != nil {
+			return result, total, err
+		}
+		result = append(result, record)
+	}
+	return result, total, rows.Err()
+}
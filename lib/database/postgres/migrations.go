@@ -0,0 +1,143 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log"
+)
+
+// migrationLockId identifies the advisory lock which serializes migrations of concurrently starting instances
+const migrationLockId = 7368421
+
+type migration struct {
+	statements []string
+}
+
+// migrations are applied in order and recorded in schema_migrations; never change or reorder existing entries
+var migrations = []migration{
+	{statements: []string{
+		`CREATE TABLE processes (
+			id text PRIMARY KEY,
+			name text NOT NULL DEFAULT '',
+			description text NOT NULL DEFAULT '',
+			publish boolean NOT NULL DEFAULT false,
+			revision bigint NOT NULL DEFAULT 0,
+			last_updated_unix bigint NOT NULL DEFAULT 0,
+			document jsonb NOT NULL
+		)`,
+		`CREATE INDEX processes_publish_idx ON processes (publish)`,
+		`CREATE INDEX processes_metadata_idx ON processes USING gin ((document -> 'metadata') jsonb_path_ops)`,
+		`CREATE TABLE process_revisions (
+			process_id text NOT NULL,
+			revision bigint NOT NULL,
+			document jsonb NOT NULL,
+			PRIMARY KEY (process_id, revision)
+		)`,
+		`CREATE TABLE process_templates (
+			id text PRIMARY KEY,
+			owner text NOT NULL DEFAULT '',
+			publish boolean NOT NULL DEFAULT false,
+			name text NOT NULL DEFAULT '',
+			description text NOT NULL DEFAULT '',
+			document jsonb NOT NULL
+		)`,
+		`CREATE INDEX process_templates_owner_idx ON process_templates (owner)`,
+		`CREATE INDEX process_templates_publish_idx ON process_templates (publish)`,
+		`CREATE TABLE process_outbox (
+			id text PRIMARY KEY,
+			process_id text NOT NULL,
+			created bigint NOT NULL,
+			document jsonb NOT NULL
+		)`,
+		`CREATE INDEX process_outbox_created_idx ON process_outbox (created, id)`,
+		`CREATE INDEX process_outbox_process_idx ON process_outbox (process_id, created)`,
+	}},
+	{statements: []string{
+		//full-text search replaces the substring search; existing rows get their search_vector
+		//when the process metadata migration saves them again (model.ProcessMetadataVersion 2)
+		`ALTER TABLE processes ADD COLUMN search_vector tsvector NOT NULL DEFAULT ''::tsvector`,
+		`CREATE INDEX processes_search_idx ON processes USING gin (search_vector)`,
+	}},
+	{statements: []string{
+		`CREATE INDEX processes_categories_idx ON processes USING gin ((document -> 'categories'))`,
+	}},
+	{statements: []string{
+		`CREATE INDEX processes_tags_idx ON processes USING gin ((document -> 'tags'))`,
+		`CREATE INDEX processes_folder_idx ON processes ((document ->> 'folder') text_pattern_ops)`,
+	}},
+	{statements: []string{
+		`CREATE TABLE process_audit (
+			id text PRIMARY KEY,
+			process_id text NOT NULL,
+			date bigint NOT NULL,
+			document jsonb NOT NULL
+		)`,
+		`CREATE INDEX process_audit_process_idx ON process_audit (process_id, date)`,
+	}},
+}
+
+func (this *Postgres) migrate(ctx context.Context) error {
+	conn, err := this.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	_, err = conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockId)
+	if err != nil {
+		return err
+	}
+	defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockId)
+	_, err = conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version integer PRIMARY KEY, applied_at timestamptz NOT NULL DEFAULT now())`)
+	if err != nil {
+		return err
+	}
+	var current int
+	err = conn.QueryRow(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current)
+	if err != nil {
+		return err
+	}
+	for i := current; i < len(migrations); i++ {
+		version := i + 1
+		err = applyMigration(ctx, conn, version, migrations[i])
+		if err != nil {
+			return err
+		}
+		log.Println("applied postgres migration", version)
+	}
+	return nil
+}
+
+func applyMigration(ctx context.Context, conn *pgxpool.Conn, version int, m migration) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+	for _, statement := range m.statements {
+		_, err = tx.Exec(ctx, statement)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, version)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
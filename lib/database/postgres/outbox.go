@@ -0,0 +1,87 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+)
+
+const outboxNotParked = `COALESCE((document ->> 'parked')::boolean, false) = false`
+const outboxClaimedUntil = `COALESCE((document ->> 'claimed_until')::bigint, 0)`
+
+func (this *Postgres) SetOutboxEntry(ctx context.Context, entry model.OutboxEntry) error {
+	document, err := marshalDocument(entry)
+	if err != nil {
+		return err
+	}
+	_, err = this.db(ctx).Exec(ctx, `INSERT INTO process_outbox (id, process_id, created, document) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET process_id = EXCLUDED.process_id, created = EXCLUDED.created, document = EXCLUDED.document`,
+		entry.Id, entry.ProcessId, entry.Created, document)
+	return err
+}
+
+func (this *Postgres) DeleteOutboxEntry(ctx context.Context, id string) error {
+	_, err := this.db(ctx).Exec(ctx, `DELETE FROM process_outbox WHERE id = $1`, id)
+	return err
+}
+
+func (this *Postgres) ListOutboxEntries(ctx context.Context, processId string, due int64, limit int64) (result []model.OutboxEntry, err error) {
+	query := `SELECT document FROM process_outbox WHERE ($1 = '' OR process_id = $1) AND ` + outboxNotParked + `
+		AND ($2::bigint = 0 OR ((document ->> 'next_attempt')::bigint <= $2::bigint AND ` + outboxClaimedUntil + ` <= $2::bigint))
+		ORDER BY created, id`
+	args := []interface{}{processId, due}
+	if limit > 0 {
+		query = query + ` LIMIT $3`
+		args = append(args, limit)
+	}
+	rows, err := this.db(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	result = []model.OutboxEntry{}
+	for rows.Next() {
+		var document []byte
+		err = rows.Scan(&document)
+		if err != nil {
+			return result, err
+		}
+		entry := model.OutboxEntry{}
+		err = json.Unmarshal(document, &entry)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, entry)
+	}
+	return result, rows.Err()
+}
+
+func (this *Postgres) ClaimOutboxEntry(ctx context.Context, id string, now int64, until int64) (claimed bool, err error) {
+	tag, err := this.db(ctx).Exec(ctx, `UPDATE process_outbox SET document = jsonb_set(document, '{claimed_until}', to_jsonb($3::bigint))
+		WHERE id = $1 AND `+outboxNotParked+` AND `+outboxClaimedUntil+` <= $2::bigint`, id, now, until)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (this *Postgres) CountOutboxEntries(ctx context.Context, processId string) (count int64, err error) {
+	err = this.db(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM process_outbox WHERE process_id = $1`, processId).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/SENERGY-Platform/process-model-repository/lib/config"
+	"github.com/SENERGY-Platform/process-model-repository/lib/contextwg"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const TIMEOUT = 10 * time.Second
+
+// Postgres implements database.Database with documents stored as jsonb.
+// columns used for filters and constraints are duplicated next to the document.
+type Postgres struct {
+	config config.Config
+	pool   *pgxpool.Pool
+}
+
+// querier is implemented by *pgxpool.Pool and pgx.Tx
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type transactionKey struct{}
+
+func New(ctx context.Context, conf config.Config) (*Postgres, error) {
+	timeout, cancel := context.WithTimeout(ctx, TIMEOUT)
+	defer cancel()
+	pool, err := pgxpool.New(ctx, conf.PostgresUrl)
+	if err != nil {
+		return nil, err
+	}
+	err = pool.Ping(timeout)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+	db := &Postgres{config: conf, pool: pool}
+	err = db.migrate(timeout)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+	contextwg.Add(ctx, 1)
+	go func() {
+		<-ctx.Done()
+		pool.Close()
+		log.Println("disconnected from postgres")
+		contextwg.Done(ctx)
+	}()
+	return db, nil
+}
+
+func (this *Postgres) CreateId() string {
+	return uuid.NewString()
+}
+
+// Transaction starts a transaction, which is used by all calls with the returned context until close is called
+func (this *Postgres) Transaction(ctx context.Context) (resultCtx context.Context, close func(success bool) error, err error) {
+	tx, err := this.pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	resultCtx = context.WithValue(ctx, transactionKey{}, tx)
+	return resultCtx, func(success bool) error {
+		var err error
+		if success {
+			err = tx.Commit(context.Background())
+		} else {
+			err = tx.Rollback(context.Background())
+		}
+		if err != nil {
+			log.Println("ERROR: unable to finish postgres transaction", err)
+		}
+		return err
+	}, nil
+}
+
+func (this *Postgres) db(ctx context.Context) querier {
+	if tx, ok := ctx.Value(transactionKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return this.pool
+}
+
+func marshalDocument(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func isNoRows(err error) bool {
+	return errors.Is(err, pgx.ErrNoRows)
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// sortClause returns an ORDER BY expression for a sort parameter like "name.desc" on the jsonb column.
+// the field path uses the json/bson field names of the models. like in mongodb, values are ordered by
+// type (null, numbers, strings, booleans) and strings are compared bytewise.
+func sortClause(column string, sortBy string, argIndex int) (clause string, path []string) {
+	field, direction := parseSort(sortBy)
+	keys := []string{}
+	for _, key := range sortKeys("(" + column + " #> $" + strconv.Itoa(argIndex) + "::text[])") {
+		keys = append(keys, key+" "+direction)
+	}
+	return strings.Join(keys, ", "), strings.Split(field, ".")
+}
+
+// cursorCondition matches the rows sorted behind the (value, id) position in a list ordered by
+// sortClause(column, sortBy, pathArgIndex) and the id column in the same direction.
+// valueArgIndex references the jsonb sort value of the position, idArgIndex its id.
+func cursorCondition(column string, sortBy string, pathArgIndex int, valueArgIndex int, idArgIndex int) string {
+	_, direction := parseSort(sortBy)
+	operator := ">"
+	if direction == "DESC" {
+		operator = "<"
+	}
+	rowKeys := append(sortKeys("("+column+" #> $"+strconv.Itoa(pathArgIndex)+"::text[])"), `id COLLATE "C"`)
+	positionKeys := append(sortKeys("$"+strconv.Itoa(valueArgIndex)+"::jsonb"), "$"+strconv.Itoa(idArgIndex)+"::text")
+	return "(" + strings.Join(rowKeys, ", ") + ") " + operator + " (" + strings.Join(positionKeys, ", ") + ")"
+}
+
+// sortKeys returns the expressions ordering a jsonb value: type rank, numeric value and bytewise text.
+// the keys are never null, so they can be used in row comparisons.
+func sortKeys(value string) []string {
+	text := "(" + value + " #>> '{}')"
+	return []string{
+		"CASE jsonb_typeof(" + value + ") WHEN 'number' THEN 1 WHEN 'string' THEN 2 WHEN 'boolean' THEN 3 ELSE 0 END",
+		"COALESCE(CASE WHEN jsonb_typeof(" + value + ") = 'number' THEN " + text + "::numeric END, 0)",
+		"COALESCE(CASE WHEN jsonb_typeof(" + value + ") <> 'number' THEN " + text + " END, '') COLLATE \"C\"",
+	}
+}
+
+func parseSort(sortBy string) (field string, direction string) {
+	if sortBy == "" {
+		sortBy = "name.asc"
+	}
+	direction = "ASC"
+	if strings.HasSuffix(sortBy, ".desc") {
+		direction = "DESC"
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(sortBy, ".asc"), ".desc"), direction
+}
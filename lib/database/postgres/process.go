@@ -0,0 +1,318 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var CleanupLastUpdateTimeBuffer = time.Minute
+
+// searchVectorSql weights the words of name ($8), description ($9) and bpmn texts ($10) like the mongo text index.
+// the words are split by model.SearchWords, so the 'simple' configuration only has to separate them by spaces.
+const searchVectorSql = `setweight(to_tsvector('simple', $8), 'A') || setweight(to_tsvector('simple', $9), 'B') || setweight(to_tsvector('simple', $10), 'C')`
+
+// searchRankWeights are the ts_rank weights of {D, C, B, A}, relative to model.SearchWeightName
+const searchRankWeights = `'{0, 0.1, 0.5, 1}'`
+
+const upsertProcessSql = `INSERT INTO processes (id, name, description, publish, revision, last_updated_unix, document, search_vector)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, ` + searchVectorSql + `)
+	ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, description = EXCLUDED.description, publish = EXCLUDED.publish,
+		revision = EXCLUDED.revision, last_updated_unix = EXCLUDED.last_updated_unix, document = EXCLUDED.document, search_vector = EXCLUDED.search_vector`
+
+func processArgs(process model.Process) ([]interface{}, error) {
+	if process.LastUpdatedUnix == 0 {
+		process.LastUpdatedUnix = time.Now().Unix()
+	}
+	process.Highlights = nil
+	document, err := marshalDocument(process)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{process.Id, process.Name, process.Description, process.Publish, process.Revision, process.LastUpdatedUnix, document,
+		searchWords(process.Name), searchWords(process.Description), searchWords(process.Metadata.Texts...)}, nil
+}
+
+func searchWords(texts ...string) string {
+	return strings.Join(model.SearchWords(strings.Join(texts, " ")), " ")
+}
+
+func (this *Postgres) ReadProcess(ctx context.Context, id string) (process model.Process, exists bool, err error) {
+	var document []byte
+	err = this.db(ctx).QueryRow(ctx, `SELECT document FROM processes WHERE id = $1`, id).Scan(&document)
+	if isNoRows(err) {
+		return process, false, nil
+	}
+	if err != nil {
+		return process, false, err
+	}
+	err = json.Unmarshal(document, &process)
+	return process, err == nil, err
+}
+
+// projectedDocument selects the top level document keys listed in the text array parameter argIndex
+func projectedDocument(argIndex int) string {
+	return `COALESCE((SELECT jsonb_object_agg(key, value) FROM jsonb_each(document) WHERE key = ANY($` + strconv.Itoa(argIndex) + `::text[])), '{}'::jsonb)`
+}
+
+func (this *Postgres) SetProcess(ctx context.Context, process model.Process) error {
+	args, err := processArgs(process)
+	if err != nil {
+		return err
+	}
+	_, err = this.db(ctx).Exec(ctx, upsertProcessSql, args...)
+	return err
+}
+
+// SetProcessIfRevision replaces the process only if the stored revision equals expectedRevision.
+// expectedRevision 0 creates missing processes.
+func (this *Postgres) SetProcessIfRevision(ctx context.Context, process model.Process, expectedRevision int64) (ok bool, err error) {
+	args, err := processArgs(process)
+	if err != nil {
+		return false, err
+	}
+	if expectedRevision == 0 {
+		tag, err := this.db(ctx).Exec(ctx, upsertProcessSql+` WHERE processes.revision = 0`, args...)
+		if err != nil {
+			return false, err
+		}
+		return tag.RowsAffected() > 0, nil
+	}
+	tag, err := this.db(ctx).Exec(ctx, `UPDATE processes SET name = $2, description = $3, publish = $4, revision = $5, last_updated_unix = $6, document = $7,
+		search_vector = `+searchVectorSql+` WHERE id = $1 AND revision = $11`, append(args, expectedRevision)...)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (this *Postgres) DeleteProcess(ctx context.Context, id string) error {
+	_, err := this.db(ctx).Exec(ctx, `DELETE FROM processes WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	_, err = this.db(ctx).Exec(ctx, `DELETE FROM process_revisions WHERE process_id = $1`, id)
+	return err
+}
+
+func (this *Postgres) ListProcesses(ctx context.Context, listOptions model.ListOptions) (result []model.Process, total int64, err error) {
+	search := strings.TrimSpace(listOptions.Search)
+	terms := model.SearchTerms(search)
+	if search != "" && len(terms) == 0 {
+		return result, total, nil
+	}
+	where, args := processFilter(listOptions, terms)
+	if !listOptions.WithoutTotal {
+		err = this.db(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM processes WHERE `+where, args...).Scan(&total)
+		if err != nil {
+			return result, total, err
+		}
+	}
+	sortBy := listOptions.SortBy
+	rank := ""
+	if strings.HasPrefix(sortBy, model.SearchScoreSort+".") {
+		//relevance ties and lists without search are sorted by name
+		sortBy = "name.asc"
+		if len(terms) > 0 {
+			args = append(args, searchQuery(terms))
+			rank = `ts_rank(` + searchRankWeights + `, search_vector, to_tsquery('simple', $` + strconv.Itoa(len(args)) + `)) DESC, `
+		}
+	}
+	order, path := sortClause("document", sortBy, len(args)+1)
+	args = append(args, path)
+	pathArgIndex := len(args)
+	_, direction := parseSort(sortBy)
+	//id as tiebreaker makes the order stable, which is required for continuation tokens
+	order = order + `, id COLLATE "C" ` + direction
+	if listOptions.After != nil {
+		value, err := json.Marshal(listOptions.After.Value)
+		if err != nil {
+			return result, total, err
+		}
+		args = append(args, value, listOptions.After.Id)
+		where = where + ` AND ` + cursorCondition("document", sortBy, pathArgIndex, len(args)-1, len(args))
+	}
+	document := "document"
+	if listOptions.Fields != nil {
+		args = append(args, append([]string{"_id"}, listOptions.Fields...))
+		document = projectedDocument(len(args))
+	}
+	query := `SELECT ` + document + ` FROM processes WHERE ` + where + ` ORDER BY ` + rank + order
+	if listOptions.Limit > 0 {
+		args = append(args, listOptions.Limit)
+		query = query + ` LIMIT $` + strconv.Itoa(len(args))
+	}
+	if listOptions.Offset > 0 && listOptions.After == nil {
+		args = append(args, listOptions.Offset)
+		query = query + ` OFFSET $` + strconv.Itoa(len(args))
+	}
+	result, err = this.queryProcesses(ctx, query, args...)
+	return result, total, err
+}
+
+func (this *Postgres) CountProcessValues(ctx context.Context, field string, listOptions model.ListOptions) (result []model.ValueCount, err error) {
+	result = []model.ValueCount{}
+	terms := model.SearchTerms(listOptions.Search)
+	if strings.TrimSpace(listOptions.Search) != "" && len(terms) == 0 {
+		return result, nil
+	}
+	where, args := processFilter(listOptions, terms)
+	args = append(args, field)
+	values := `document -> $` + strconv.Itoa(len(args)) + `::text`
+	rows, err := this.db(ctx).Query(ctx, `SELECT value, COUNT(*) FROM processes,
+		jsonb_array_elements_text(CASE jsonb_typeof(`+values+`)
+			WHEN 'array' THEN `+values+`
+			WHEN 'string' THEN jsonb_build_array(`+values+`)
+			ELSE '[]'::jsonb END) AS value
+		WHERE `+where+` AND value <> '' GROUP BY value ORDER BY value COLLATE "C"`, args...)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		count := model.ValueCount{}
+		err = rows.Scan(&count.Value, &count.Count)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, count)
+	}
+	return result, rows.Err()
+}
+
+// searchQuery requires every term; terms only contain letters and digits, so they need no escaping
+func searchQuery(terms []string) string {
+	return strings.Join(terms, " & ")
+}
+
+func processFilter(listOptions model.ListOptions, searchTerms []string) (where string, args []interface{}) {
+	conditions := []string{"TRUE"}
+	add := func(condition string, values ...interface{}) {
+		for _, value := range values {
+			args = append(args, value)
+			condition = strings.Replace(condition, "?", "$"+strconv.Itoa(len(args)), 1)
+		}
+		conditions = append(conditions, condition)
+	}
+	if listOptions.Ids != nil {
+		add("id = ANY(?)", listOptions.Ids)
+	}
+	if listOptions.Public {
+		add("publish")
+	}
+	if listOptions.Removed {
+		add("jsonb_typeof(document -> 'removal') = 'object'")
+	} else {
+		add("jsonb_typeof(document -> 'removal') IS DISTINCT FROM 'object'")
+	}
+	if listOptions.Category != "" {
+		add("document -> 'categories' @> jsonb_build_array(?::text)", listOptions.Category)
+	}
+	if listOptions.Tag != "" {
+		add("document -> 'tags' @> jsonb_build_array(?::text)", listOptions.Tag)
+	}
+	if listOptions.Folder != "" {
+		add("(document ->> 'folder' = ? OR starts_with(document ->> 'folder', ?))", listOptions.Folder, listOptions.Folder+"/")
+	}
+	if len(searchTerms) > 0 {
+		add("search_vector @@ to_tsquery('simple', ?)", searchQuery(searchTerms))
+	}
+	for key, value := range map[string]string{
+		"topics":            listOptions.Topic,
+		"messages":          listOptions.Message,
+		"signals":           listOptions.Signal,
+		"lanes":             listOptions.Lane,
+		"input_parameters":  listOptions.InputParameter,
+		"output_parameters": listOptions.OutputParameter,
+	} {
+		if value != "" {
+			add("document -> 'metadata' @> jsonb_build_object(?::text, jsonb_build_array(?::text))", key, value)
+		}
+	}
+	if listOptions.TimerType != "" {
+		add("document -> 'metadata' @> jsonb_build_object('timers', jsonb_build_array(jsonb_build_object('type', ?::text)))", listOptions.TimerType)
+	}
+	if listOptions.MinTaskCount > 0 {
+		add("(document -> 'metadata' ->> 'task_count')::bigint >= ?", listOptions.MinTaskCount)
+	}
+	if listOptions.MaxTaskCount > 0 {
+		add("(document -> 'metadata' ->> 'task_count')::bigint <= ?", listOptions.MaxTaskCount)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+func (this *Postgres) queryProcesses(ctx context.Context, query string, args ...interface{}) (result []model.Process, err error) {
+	rows, err := this.db(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var document []byte
+		err = rows.Scan(&document)
+		if err != nil {
+			return result, err
+		}
+		process := model.Process{}
+		err = json.Unmarshal(document, &process)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, process)
+	}
+	return result, rows.Err()
+}
+
+func (this *Postgres) CheckIdList(ids []string) (missingInDb []string, missingInInput []string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	defer cancel()
+	if ids == nil {
+		ids = []string{}
+	}
+	missingInDb, err = this.queryIds(ctx, `SELECT input.id FROM unnest($1::text[]) AS input(id) WHERE NOT EXISTS (SELECT 1 FROM processes WHERE processes.id = input.id)`, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	missingInInput, err = this.queryIds(ctx, `SELECT id FROM processes WHERE NOT (id = ANY($1)) AND last_updated_unix < $2
+		AND jsonb_typeof(document -> 'removal') IS DISTINCT FROM 'object'`, ids, time.Now().Add(-CleanupLastUpdateTimeBuffer).Unix())
+	if err != nil {
+		return nil, nil, err
+	}
+	return missingInDb, missingInInput, nil
+}
+
+func (this *Postgres) queryIds(ctx context.Context, query string, args ...interface{}) (result []string, err error) {
+	rows, err := this.pool.Query(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, id)
+	}
+	return result, rows.Err()
+}
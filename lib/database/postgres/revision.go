@@ -0,0 +1,79 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+)
+
+func (this *Postgres) SetProcessRevision(ctx context.Context, revision model.ProcessRevision) error {
+	document, err := marshalDocument(revision)
+	if err != nil {
+		return err
+	}
+	_, err = this.db(ctx).Exec(ctx, `INSERT INTO process_revisions (process_id, revision, document) VALUES ($1, $2, $3)
+		ON CONFLICT (process_id, revision) DO UPDATE SET document = EXCLUDED.document`, revision.ProcessId, revision.Revision, document)
+	return err
+}
+
+func (this *Postgres) ReadProcessRevision(ctx context.Context, processId string, revision int64) (result model.ProcessRevision, exists bool, err error) {
+	var document []byte
+	err = this.db(ctx).QueryRow(ctx, `SELECT document FROM process_revisions WHERE process_id = $1 AND revision = $2`, processId, revision).Scan(&document)
+	if isNoRows(err) {
+		return result, false, nil
+	}
+	if err != nil {
+		return result, false, err
+	}
+	err = json.Unmarshal(document, &result)
+	return result, err == nil, err
+}
+
+func (this *Postgres) ListProcessRevisions(ctx context.Context, processId string, limit int64, offset int64) (result []model.ProcessRevision, total int64, err error) {
+	err = this.db(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM process_revisions WHERE process_id = $1`, processId).Scan(&total)
+	if err != nil {
+		return result, total, err
+	}
+	query := `SELECT document #- '{process,bpmn_xml}' #- '{process,svgXML}' FROM process_revisions WHERE process_id = $1 ORDER BY revision DESC OFFSET $2`
+	args := []interface{}{processId, offset}
+	if limit > 0 {
+		query = query + ` LIMIT $3`
+		args = append(args, limit)
+	}
+	rows, err := this.db(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return result, total, err
+	}
+	defer rows.Close()
+	result = []model.ProcessRevision{}
+	for rows.Next() {
+		var document []byte
+		err = rows.Scan(&document)
+		if err != nil {
+			return result, total, err
+		}
+		revision := model.ProcessRevision{}
+		err = json.Unmarshal(document, &revision)
+		if err != nil {
+			return result, total, err
+		}
+		result = append(result, revision)
+	}
+	return result, total, rows.Err()
+}
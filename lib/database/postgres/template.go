@@ -0,0 +1,113 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (this *Postgres) ReadTemplate(ctx context.Context, id string) (result model.ProcessTemplate, exists bool, err error) {
+	var document []byte
+	err = this.db(ctx).QueryRow(ctx, `SELECT document FROM process_templates WHERE id = $1`, id).Scan(&document)
+	if isNoRows(err) {
+		return result, false, nil
+	}
+	if err != nil {
+		return result, false, err
+	}
+	err = json.Unmarshal(document, &result)
+	return result, err == nil, err
+}
+
+func (this *Postgres) SetTemplate(ctx context.Context, template model.ProcessTemplate) error {
+	if template.LastUpdatedUnix == 0 {
+		template.LastUpdatedUnix = time.Now().Unix()
+	}
+	document, err := marshalDocument(template)
+	if err != nil {
+		return err
+	}
+	_, err = this.db(ctx).Exec(ctx, `INSERT INTO process_templates (id, owner, publish, name, description, document) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET owner = EXCLUDED.owner, publish = EXCLUDED.publish, name = EXCLUDED.name, description = EXCLUDED.description, document = EXCLUDED.document`,
+		template.Id, template.Owner, template.Publish, template.Name, template.Description, document)
+	return err
+}
+
+func (this *Postgres) DeleteTemplate(ctx context.Context, id string) error {
+	_, err := this.db(ctx).Exec(ctx, `DELETE FROM process_templates WHERE id = $1`, id)
+	return err
+}
+
+func (this *Postgres) ListTemplates(ctx context.Context, listOptions model.TemplateListOptions) (result []model.ProcessTemplate, total int64, err error) {
+	conditions := []string{"TRUE"}
+	args := []interface{}{}
+	if listOptions.Owner != "" {
+		args = append(args, listOptions.Owner)
+		if listOptions.IncludePublished {
+			conditions = append(conditions, "(owner = $1 OR publish)")
+		} else {
+			conditions = append(conditions, "owner = $1")
+		}
+	}
+	search := strings.TrimSpace(listOptions.Search)
+	if search != "" {
+		args = append(args, "%"+escapeLike(search)+"%")
+		placeholder := "$" + strconv.Itoa(len(args))
+		conditions = append(conditions, "(name ILIKE "+placeholder+" OR description ILIKE "+placeholder+")")
+	}
+	where := strings.Join(conditions, " AND ")
+	err = this.db(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM process_templates WHERE `+where, args...).Scan(&total)
+	if err != nil {
+		return result, total, err
+	}
+	order, path := sortClause("document", listOptions.SortBy, len(args)+1)
+	args = append(args, path)
+	query := `SELECT document FROM process_templates WHERE ` + where + ` ORDER BY ` + order + `, id`
+	if listOptions.Limit > 0 {
+		args = append(args, listOptions.Limit)
+		query = query + ` LIMIT $` + strconv.Itoa(len(args))
+	}
+	if listOptions.Offset > 0 {
+		args = append(args, listOptions.Offset)
+		query = query + ` OFFSET $` + strconv.Itoa(len(args))
+	}
+	rows, err := this.db(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return result, total, err
+	}
+	defer rows.Close()
+	result = []model.ProcessTemplate{}
+	for rows.Next() {
+		var document []byte
+		err = rows.Scan(&document)
+		if err != nil {
+			return result, total, err
+		}
+		template := model.ProcessTemplate{}
+		err = json.Unmarshal(document, &template)
+		if err != nil {
+			return result, total, err
+		}
+		result = append(result, template)
+	}
+	return result, total, rows.Err()
+}
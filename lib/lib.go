@@ -23,6 +23,7 @@ import (
 	"github.com/SENERGY-Platform/process-model-repository/lib/controller"
 	"github.com/SENERGY-Platform/process-model-repository/lib/database"
 	"github.com/SENERGY-Platform/process-model-repository/lib/source/consumer"
+	"github.com/SENERGY-Platform/process-model-repository/lib/source/producer"
 	"log"
 	"time"
 )
@@ -58,7 +59,16 @@ func StartGetInternals(basectx context.Context, conf config.Config) (db database
 		return db, ctrl, err
 	}
 
-	ctrl, err = controller.New(conf, db)
+	var changeProducer controller.Producer
+	if conf.ProcessChangeTopic != "" {
+		changeProducer, err = producer.New(ctx, conf)
+		if err != nil {
+			log.Println("ERROR: unable to start producer", err)
+			return db, ctrl, err
+		}
+	}
+
+	ctrl, err = controller.New(conf, db, changeProducer)
 	if err != nil {
 		log.Println("ERROR: unable to start control", err)
 		return db, ctrl, err
@@ -71,6 +81,13 @@ func StartGetInternals(basectx context.Context, conf config.Config) (db database
 	}
 	ctrl.StartCleanupLoop(ctx, cleanupInterval)
 
+	outboxDispatchInterval, err := time.ParseDuration(conf.OutboxDispatchInterval)
+	if err != nil {
+		log.Println("ERROR: unable to parse outbox dispatch interval", err)
+		return db, ctrl, err
+	}
+	ctrl.StartOutboxDispatcher(ctx, outboxDispatchInterval)
+
 	err = consumer.Start(ctx, conf, ctrl)
 	if err != nil {
 		log.Println("ERROR: unable to start source", err)
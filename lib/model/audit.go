@@ -0,0 +1,38 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+type AuditAction string
+
+const (
+	AuditPermissionsChange AuditAction = "permissions"
+	AuditOwnerChange       AuditAction = "owner"
+)
+
+// AuditRecord documents a change of the access to a process.
+// records are kept after the process is deleted.
+type AuditRecord struct {
+	Id                string              `json:"id" bson:"_id"` //time ordered uuid (v7)
+	ProcessId         string              `json:"process_id" bson:"process_id"`
+	Action            AuditAction         `json:"action" bson:"action"`
+	UserId            string              `json:"user_id" bson:"user_id"` //user who made the change; empty for changes made by the service (e.g. on user delete)
+	Date              int64               `json:"date" bson:"date"`       //unix
+	PermissionsBefore *ProcessPermissions `json:"permissions_before,omitempty" bson:"permissions_before,omitempty"`
+	PermissionsAfter  *ProcessPermissions `json:"permissions_after,omitempty" bson:"permissions_after,omitempty"`
+	OwnerBefore       string              `json:"owner_before,omitempty" bson:"owner_before,omitempty"`
+	OwnerAfter        string              `json:"owner_after,omitempty" bson:"owner_after,omitempty"`
+}
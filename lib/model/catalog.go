@@ -0,0 +1,65 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ProcessCategoriesField is the process field containing the categories of published processes
+const ProcessCategoriesField = "categories"
+
+const MaxCategories = 10
+const MaxCategoryLength = 64
+
+// ValueCount is the number of processes containing a value, e.g. a category
+type ValueCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// NormalizeCategories returns the trimmed, distinct and sorted categories
+func NormalizeCategories(categories []string) (result []string, err error) {
+	return normalizeLabels(categories, "category", "categories", MaxCategories, MaxCategoryLength)
+}
+
+// normalizeLabels trims, deduplicates and sorts values like categories or tags
+func normalizeLabels(values []string, singular string, plural string, maxCount int, maxLength int) (result []string, err error) {
+	known := map[string]bool{}
+	result = []string{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, fmt.Errorf("empty %v", singular)
+		}
+		if utf8.RuneCountInString(value) > maxLength {
+			return nil, fmt.Errorf("%v '%v' is longer than %v characters", singular, value, maxLength)
+		}
+		if !known[value] {
+			known[value] = true
+			result = append(result, value)
+		}
+	}
+	if len(result) > maxCount {
+		return nil, fmt.Errorf("more than %v %v", maxCount, plural)
+	}
+	sort.Strings(result)
+	return result, nil
+}
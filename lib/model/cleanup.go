@@ -0,0 +1,31 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+// CleanupReport describes one reconciliation of the stored processes with the permissions-v2 resources
+type CleanupReport struct {
+	Start             int64    `json:"start"` //unix
+	End               int64    `json:"end"`   //unix
+	DryRun            bool     `json:"dry_run"`
+	Applied           bool     `json:"applied"`                //false for dry runs, aborted and failed cleanups
+	ProcessCount      int64    `json:"process_count"`          //stored processes, including removed processes
+	ResourceCount     int64    `json:"resource_count"`         //permissions-v2 resources of processes
+	OrphanedResources []string `json:"orphaned_resources"`     //ids of permissions-v2 resources without stored process
+	OrphanedProcesses []string `json:"orphaned_processes"`     //ids of stored processes without permissions-v2 resource
+	AbortReason       string   `json:"abort_reason,omitempty"` //set if the safety threshold was exceeded; "; " separated if exceeded by permissions and processes
+	Error             string   `json:"error,omitempty"`
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ListCursor is the position of the last element of a process list page.
+// lists sorted by SortBy and _id continue after this position, independent of inserts or deletes on previous pages.
+type ListCursor struct {
+	SortBy string      `json:"s"`
+	Value  interface{} `json:"v"` //json value of the sort field; nil if the field is missing
+	Id     string      `json:"i"`
+}
+
+// NewListCursor returns the cursor pointing after process in a list sorted by sortBy
+func NewListCursor(sortBy string, process Process) (result ListCursor, err error) {
+	if sortBy == "" {
+		sortBy = "name.asc"
+	}
+	result = ListCursor{SortBy: sortBy, Id: process.Id}
+	temp, err := json.Marshal(process)
+	if err != nil {
+		return result, err
+	}
+	var value interface{}
+	err = json.Unmarshal(temp, &value)
+	if err != nil {
+		return result, err
+	}
+	field := strings.TrimSuffix(strings.TrimSuffix(sortBy, ".asc"), ".desc")
+	for _, name := range strings.Split(field, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return result, nil
+		}
+		value = object[name]
+	}
+	switch value.(type) {
+	case string, float64, bool:
+		result.Value = value
+	}
+	return result, nil
+}
+
+// Encode returns the cursor as opaque url safe token
+func (this ListCursor) Encode() (string, error) {
+	temp, err := json.Marshal(this)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(temp), nil
+}
+
+// ParseListCursor decodes a token created by ListCursor.Encode
+func ParseListCursor(token string) (result ListCursor, err error) {
+	temp, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return result, errors.New("invalid continuation token")
+	}
+	err = json.Unmarshal(temp, &result)
+	if err != nil || result.Id == "" {
+		return result, errors.New("invalid continuation token")
+	}
+	switch result.Value.(type) {
+	case nil, string, float64, bool:
+	default:
+		return result, errors.New("invalid continuation token")
+	}
+	return result, nil
+}
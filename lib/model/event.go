@@ -0,0 +1,74 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+// ProcessChangeEventVersion is incremented on incompatible changes of ProcessChangeEvent
+// (2: Process is a ProcessChangeSummary instead of the full document)
+const ProcessChangeEventVersion int64 = 2
+
+type ProcessChangeCommand string
+
+const (
+	ProcessChangePut    ProcessChangeCommand = "PUT"
+	ProcessChangeDelete ProcessChangeCommand = "DELETE"
+)
+
+// ProcessChangeEvent is published to the process change topic, keyed by the process id,
+// after a process has been stored or deleted.
+// processes moved into the trash or the user delete archive are published as DELETE, restored processes as PUT;
+// purging a removed process publishes DELETE again.
+// the event carries no bpmn and svg, which may exceed the max message size of kafka; consumers read them with GET /processes/:id
+type ProcessChangeEvent struct {
+	Version  int64                 `json:"version"`
+	Command  ProcessChangeCommand  `json:"command"`
+	Id       string                `json:"id"`
+	UserId   string                `json:"user_id"` //user who triggered the change; empty for system changes
+	Revision int64                 `json:"revision,omitempty"`
+	Time     int64                 `json:"time"`              //unix timestamp of the change; process.last_updated_unix for PUT
+	Process  *ProcessChangeSummary `json:"process,omitempty"` //nil for DELETE
+}
+
+// ProcessChangeSummary is the process without bpmn and svg
+type ProcessChangeSummary struct {
+	Id              string          `json:"_id"`
+	Name            string          `json:"name"`
+	Description     string          `json:"description"`
+	Owner           string          `json:"owner"`
+	Publish         bool            `json:"publish"`
+	Revision        int64           `json:"revision"`
+	LastUpdatedUnix int64           `json:"last_updated_unix"`
+	Metadata        ProcessMetadata `json:"metadata"`
+	Categories      []string        `json:"categories"`
+	Tags            []string        `json:"tags"`
+	Folder          string          `json:"folder"`
+}
+
+func NewProcessChangeSummary(process Process) ProcessChangeSummary {
+	return ProcessChangeSummary{
+		Id:              process.Id,
+		Name:            process.Name,
+		Description:     process.Description,
+		Owner:           process.Owner,
+		Publish:         process.Publish,
+		Revision:        process.Revision,
+		LastUpdatedUnix: process.LastUpdatedUnix,
+		Metadata:        process.Metadata,
+		Categories:      process.Categories,
+		Tags:            process.Tags,
+		Folder:          process.Folder,
+	}
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"github.com/beevik/etree"
+	"log"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+// ProcessMetadataVersion is incremented if ExtractProcessMetadata changes; stored metadata with a lower version is recomputed by the startup migration
+const ProcessMetadataVersion = 2
+
+// ProcessMetadata contains fields derived from the bpmn at save time.
+// it is read only for clients and allows filtering of process lists.
+type ProcessMetadata struct {
+	Version          int               `json:"version" bson:"version"`
+	TaskCount        int64             `json:"task_count" bson:"task_count"`
+	Lanes            []string          `json:"lanes" bson:"lanes"`
+	Messages         []string          `json:"messages" bson:"messages"`
+	Signals          []string          `json:"signals" bson:"signals"`
+	Timers           []TimerDefinition `json:"timers" bson:"timers"`
+	Topics           []string          `json:"topics" bson:"topics"` //camunda external task topics
+	InputParameters  []string          `json:"input_parameters" bson:"input_parameters"`
+	OutputParameters []string          `json:"output_parameters" bson:"output_parameters"`
+	Texts            []string          `json:"texts" bson:"texts"` //names and documentation of bpmn elements; part of the full-text search
+}
+
+type TimerDefinition struct {
+	ElementId string `json:"element_id" bson:"element_id"`
+	Type      string `json:"type" bson:"type"` //timeDate, timeDuration or timeCycle
+	Value     string `json:"value" bson:"value"`
+}
+
+// ExtractProcessMetadata derives ProcessMetadata from the bpmn.
+// unparsable bpmn results in empty metadata.
+func ExtractProcessMetadata(bpmn string) (result ProcessMetadata) {
+	result = ProcessMetadata{
+		Version:          ProcessMetadataVersion,
+		Lanes:            []string{},
+		Messages:         []string{},
+		Signals:          []string{},
+		Timers:           []TimerDefinition{},
+		Topics:           []string{},
+		InputParameters:  []string{},
+		OutputParameters: []string{},
+		Texts:            []string{},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("%s: %s", r, debug.Stack())
+		}
+	}()
+	doc := etree.NewDocument()
+	err := doc.ReadFromString(bpmn)
+	if err != nil {
+		return result
+	}
+	lanes := map[string]bool{}
+	messages := map[string]bool{}
+	signals := map[string]bool{}
+	topics := map[string]bool{}
+	inputs := map[string]bool{}
+	outputs := map[string]bool{}
+	texts := map[string]bool{}
+	for _, element := range doc.FindElements("//*") {
+		if element.Space == "bpmn" {
+			addName(texts, element)
+			if element.Tag == "documentation" {
+				if text := strings.TrimSpace(element.Text()); text != "" {
+					texts[text] = true
+				}
+			}
+			switch {
+			case element.Tag == "task" || strings.HasSuffix(element.Tag, "Task"):
+				result.TaskCount++
+			case element.Tag == "lane":
+				addName(lanes, element)
+			case element.Tag == "message":
+				addName(messages, element)
+			case element.Tag == "signal":
+				addName(signals, element)
+			case element.Tag == "timerEventDefinition":
+				eventId := ""
+				if parent := element.Parent(); parent != nil {
+					eventId = parent.SelectAttrValue("id", "")
+				}
+				for _, child := range element.ChildElements() {
+					result.Timers = append(result.Timers, TimerDefinition{
+						ElementId: eventId,
+						Type:      child.Tag,
+						Value:     strings.TrimSpace(child.Text()),
+					})
+				}
+			}
+		}
+		if element.Space == "camunda" {
+			switch element.Tag {
+			case "inputParameter":
+				addName(inputs, element)
+			case "outputParameter":
+				addName(outputs, element)
+			}
+		}
+		if topic := element.SelectAttrValue("camunda:topic", ""); topic != "" {
+			topics[topic] = true
+		}
+	}
+	result.Lanes = sortedKeys(lanes)
+	result.Messages = sortedKeys(messages)
+	result.Signals = sortedKeys(signals)
+	result.Topics = sortedKeys(topics)
+	result.InputParameters = sortedKeys(inputs)
+	result.OutputParameters = sortedKeys(outputs)
+	result.Texts = sortedKeys(texts)
+	return result
+}
+
+func addName(set map[string]bool, element *etree.Element) {
+	if name := element.SelectAttrValue("name", ""); name != "" {
+		set[name] = true
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	result := make([]string, 0, len(set))
+	for key := range set {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// process fields used to organize processes
+const (
+	ProcessTagsField   = "tags"
+	ProcessFolderField = "folder"
+)
+
+const MaxTags = 20
+const MaxTagLength = 64
+const MaxFolderLength = 256
+
+// MaxBulkSize limits the number of processes changed by one bulk command
+const MaxBulkSize = 500
+
+// BulkMoveCommand moves the processes into the folder
+type BulkMoveCommand struct {
+	Ids    []string `json:"ids"`
+	Folder string   `json:"folder"`
+}
+
+// BulkTagCommand adds and removes tags of the processes; tags in both lists are removed
+type BulkTagCommand struct {
+	Ids    []string `json:"ids"`
+	Add    []string `json:"add"`
+	Remove []string `json:"remove"`
+}
+
+// BulkResult reports the outcome of a bulk command for one process
+type BulkResult struct {
+	ProcessId string `json:"process_id"`
+	Revision  int64  `json:"revision,omitempty"` //revision after the change
+	Error     string `json:"error,omitempty"`
+}
+
+// NormalizeTags returns the trimmed, distinct and sorted tags
+func NormalizeTags(tags []string) (result []string, err error) {
+	return normalizeLabels(tags, "tag", "tags", MaxTags, MaxTagLength)
+}
+
+// NormalizeFolder returns the folder path as slash separated folder names without leading or trailing slash.
+// names are trimmed; the root folder is the empty string.
+func NormalizeFolder(folder string) (result string, err error) {
+	names := []string{}
+	for _, name := range strings.Split(folder, "/") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	result = strings.Join(names, "/")
+	if utf8.RuneCountInString(result) > MaxFolderLength {
+		return "", fmt.Errorf("folder is longer than %v characters", MaxFolderLength)
+	}
+	if strings.ContainsFunc(result, func(r rune) bool { return r < ' ' }) {
+		return "", errors.New("folder contains control characters")
+	}
+	return result, nil
+}
+
+// InFolder checks if folder is the folder parent or one of its sub folders; every folder is in the root folder ""
+func InFolder(folder string, parent string) bool {
+	return parent == "" || folder == parent || strings.HasPrefix(folder, parent+"/")
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+// OutboxEntry records the side effects (permissions-v2 and kafka) of a process change.
+// it is stored together with the change and removed after all side effects succeeded.
+type OutboxEntry struct {
+	Id           string               `json:"id" bson:"_id"`
+	Command      ProcessChangeCommand `json:"command" bson:"command"`
+	ProcessId    string               `json:"process_id" bson:"process_id"`
+	UserId       string               `json:"user_id" bson:"user_id"`                     //user who triggered the change; receives initial permissions on PUT of a new process
+	Process      *Process             `json:"process,omitempty" bson:"process,omitempty"` //nil for DELETE
+	Created      int64                `json:"created" bson:"created"`                     //unix nano; entries of a process are dispatched in this order
+	Attempts     int64                `json:"attempts" bson:"attempts"`
+	NextAttempt  int64                `json:"next_attempt" bson:"next_attempt"`   //unix
+	ClaimedUntil int64                `json:"claimed_until" bson:"claimed_until"` //unix; set while an instance executes the entry
+	Parked       bool                 `json:"parked" bson:"parked"`               //set after too many failed attempts; parked entries are not dispatched and do not block following entries
+	LastError    string               `json:"last_error" bson:"last_error"`
+	Permissions  *ProcessPermissions  `json:"permissions,omitempty" bson:"permissions,omitempty"`     //PUT: replaces the permissions in permissions-v2; nil gives new processes their initial permissions
+	KeepResource bool                 `json:"keep_resource,omitempty" bson:"keep_resource,omitempty"` //DELETE: keeps the permissions-v2 resource of processes moved into the trash
+}
+
+// CreatedUnix returns Created as unix timestamp; used as time of the change in events
+func (entry OutboxEntry) CreatedUnix() int64 {
+	return time.Unix(0, entry.Created).Unix()
+}
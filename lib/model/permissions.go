@@ -0,0 +1,98 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"errors"
+	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
+)
+
+type PermissionsMap = client.PermissionsMap
+
+// ProcessPermissions are the rights of users, groups and roles on a process, as stored in permissions-v2
+type ProcessPermissions struct {
+	UserPermissions  map[string]PermissionsMap `json:"user_permissions" bson:"user_permissions"`
+	GroupPermissions map[string]PermissionsMap `json:"group_permissions" bson:"group_permissions"`
+	RolePermissions  map[string]PermissionsMap `json:"role_permissions" bson:"role_permissions"`
+}
+
+// OwnerTransferCommand hands a process over to NewOwner, who receives all rights
+type OwnerTransferCommand struct {
+	NewOwner            string          `json:"new_owner"`
+	PreviousOwnerRights *PermissionsMap `json:"previous_owner_rights,omitempty"` //replaces the rights of the previous owner; nil keeps them; an empty map removes them
+}
+
+var ErrMissingAdministrator = errors.New("at least one user, group or role has to keep the administrate right")
+
+// Validate checks that at least one user, group or role is able to administrate the process
+func (this ProcessPermissions) Validate() error {
+	if this.HasUserAdministrator() || containsAdministrator(this.GroupPermissions) || containsAdministrator(this.RolePermissions) {
+		return nil
+	}
+	return ErrMissingAdministrator
+}
+
+// HasUserAdministrator checks if at least one user is able to administrate the process, as required by permissions-v2
+func (this ProcessPermissions) HasUserAdministrator() bool {
+	return containsAdministrator(this.UserPermissions)
+}
+
+func containsAdministrator(permissions map[string]PermissionsMap) bool {
+	for _, rights := range permissions {
+		if rights.Administrate {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalized removes entries without any right and replaces nil maps with empty maps
+func (this ProcessPermissions) Normalized() (result ProcessPermissions) {
+	return ProcessPermissions{
+		UserPermissions:  withRights(this.UserPermissions),
+		GroupPermissions: withRights(this.GroupPermissions),
+		RolePermissions:  withRights(this.RolePermissions),
+	}
+}
+
+func withRights(permissions map[string]PermissionsMap) (result map[string]PermissionsMap) {
+	result = map[string]PermissionsMap{}
+	for key, rights := range permissions {
+		if rights != (PermissionsMap{}) {
+			result[key] = rights
+		}
+	}
+	return result
+}
+
+func ProcessPermissionsFromResource(resource client.ResourcePermissions) ProcessPermissions {
+	return ProcessPermissions{
+		UserPermissions:  resource.UserPermissions,
+		GroupPermissions: resource.GroupPermissions,
+		RolePermissions:  resource.RolePermissions,
+	}.Normalized()
+}
+
+// ToResource returns the permissions-v2 representation
+func (this ProcessPermissions) ToResource() client.ResourcePermissions {
+	normalized := this.Normalized()
+	return client.ResourcePermissions{
+		UserPermissions:  normalized.UserPermissions,
+		GroupPermissions: normalized.GroupPermissions,
+		RolePermissions:  normalized.RolePermissions,
+	}
+}
@@ -2,61 +2,72 @@ package model
 
 import (
 	"errors"
-	"fmt"
-	"github.com/beevik/etree"
-	"log"
-	"runtime/debug"
 )
 
 type ListOptions struct {
-	Ids        []string //filter; ignores limit/offset if Ids != nil; ignored if Ids == nil; Ids == []string{} will return an empty list;
-	Search     string
+	Ids        []string   //filter; ignores limit/offset if Ids != nil; ignored if Ids == nil; Ids == []string{} will return an empty list;
+	Search     string     //full-text search over name, description and bpmn texts; every word has to match
 	Limit      int64      //default 100, will be ignored if 'ids' is set (Ids != nil)
 	Offset     int64      //default 0, will be ignored if 'ids' is set (Ids != nil)
-	SortBy     string     //default name.asc
+	SortBy     string     //default name.asc; score.desc sorts search results by relevance
 	Permission AuthAction //defaults to read
+
+	After        *ListCursor //continues after the cursor instead of skipping Offset elements; the cursor has to match SortBy
+	WithoutTotal bool        //skips counting all matching processes; total is returned as 0
+	Fields       []string    //json/bson keys of the loaded process fields; other fields keep their zero value; nil loads all fields
+
+	Public   bool   //only published processes
+	Category string //only processes with the category; ignored if empty
+	Tag      string //only processes with the tag; ignored if empty
+	Folder   string //only processes in the normalized folder or its sub folders (see NormalizeFolder); ignored if empty
+	Removed  bool   //only removed processes (see ProcessRemoval); removed processes are excluded otherwise
+
+	//filters on ProcessMetadata; ignored if empty
+	Topic           string
+	Message         string
+	Signal          string
+	Lane            string
+	InputParameter  string
+	OutputParameter string
+	TimerType       string
+	MinTaskCount    int64 //ignored if 0
+	MaxTaskCount    int64 //ignored if 0
 }
 
 type Process struct {
-	Id              string `json:"_id" bson:"_id"`
-	Name            string `json:"name" bson:"name"`
-	Date            int64  `json:"date" bson:"date"`
-	Owner           string `json:"owner" bson:"owner"`
-	BpmnXml         string `json:"bpmn_xml" bson:"bpmn_xml"`
-	SvgXml          string `json:"svgXML" bson:"svgXML"`
-	Publish         bool   `json:"publish" bson:"publish"`
-	PublishDate     string `json:"publish_date" bson:"publish_date"`
-	Description     string `json:"description" bson:"description"`
-	LastUpdatedUnix int64  `json:"last_updated_unix" bson:"last_updated_unix"`
+	Id              string            `json:"_id" bson:"_id"`
+	Name            string            `json:"name" bson:"name"`
+	Date            int64             `json:"date" bson:"date"`
+	Owner           string            `json:"owner" bson:"owner"`
+	BpmnXml         string            `json:"bpmn_xml" bson:"bpmn_xml"`
+	SvgXml          string            `json:"svgXML" bson:"svgXML"`
+	Publish         bool              `json:"publish" bson:"publish"`
+	PublishDate     string            `json:"publish_date" bson:"publish_date"`
+	Description     string            `json:"description" bson:"description"`
+	LastUpdatedUnix int64             `json:"last_updated_unix" bson:"last_updated_unix"`
+	Revision        int64             `json:"revision" bson:"revision"`
+	Metadata        ProcessMetadata   `json:"metadata" bson:"metadata"`                   //derived from BpmnXml on save
+	Categories      []string          `json:"categories" bson:"categories"`               //catalog categories of published processes
+	Tags            []string          `json:"tags" bson:"tags"`                           //user defined; see NormalizeTags
+	Folder          string            `json:"folder" bson:"folder"`                       //slash separated path; see NormalizeFolder
+	Removal         *ProcessRemoval   `json:"removal,omitempty" bson:"removal,omitempty"` //set while the process is removed but recoverable
+	Highlights      []SearchHighlight `json:"highlights,omitempty" bson:"-"`              //only set in search results; never stored
 }
 
 type PublicCommand struct {
-	Publish     bool   `json:"publish"`
-	Description string `json:"description"`
+	Publish     bool     `json:"publish"`
+	Description string   `json:"description"`
+	Categories  []string `json:"categories"`
 }
 
+// Validate returns a *ValidationError if the bpmn contains structural errors
 func (process *Process) Validate() (err error) {
-	defer func() {
-		if r := recover(); r != nil && err == nil {
-			log.Printf("%s: %s", r, debug.Stack())
-			err = errors.New(fmt.Sprint("Recovered Error: ", r))
-		}
-	}()
 	if process.Id == "" {
 		return errors.New("missing id")
 	}
-	doc := etree.NewDocument()
-	err = doc.ReadFromString(process.BpmnXml)
-	if err != nil {
-		return err
-	}
-	definition := doc.FindElement("//bpmn:process")
-	if definition == nil {
-		return errors.New("missing process definition")
-	}
-	id := definition.SelectAttrValue("id", "")
-	if id == "" {
-		return errors.New("missing process definition id")
+	findings := ValidateBpmn(process.BpmnXml)
+	if ContainsValidationErrors(findings) {
+		return &ValidationError{Findings: findings}
 	}
 	return nil
 }
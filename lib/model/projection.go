@@ -0,0 +1,84 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const (
+	ProcessFieldsSummary = "summary" //all fields except bpmn_xml and svgXML
+	ProcessFieldsFull    = "full"    //all fields
+)
+
+// ProcessSummaryFields are the json/bson keys of the summary representation, which excludes the large bpmn and svg
+var ProcessSummaryFields = []string{"_id", "name", "date", "owner", "publish", "publish_date", "description", "last_updated_unix", "revision", "metadata", "categories", "tags", "folder", "removal", "highlights"}
+
+// ParseProcessFields parses a comma separated list of process json keys or one of ProcessFieldsSummary and ProcessFieldsFull.
+// the result is nil for ProcessFieldsFull; the id is always part of the result.
+func ParseProcessFields(param string) (fields []string, err error) {
+	param = strings.TrimSpace(param)
+	switch param {
+	case ProcessFieldsFull:
+		return nil, nil
+	case ProcessFieldsSummary:
+		return slices.Clone(ProcessSummaryFields), nil
+	}
+	known := processJsonKeys()
+	fields = []string{"_id"}
+	for _, field := range strings.Split(param, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || slices.Contains(fields, field) {
+			continue
+		}
+		if !slices.Contains(known, field) {
+			return nil, fmt.Errorf("unknown field '%v'", field)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// ProjectProcess returns the json representation of the process, reduced to the fields; nil fields return all fields
+func ProjectProcess(process Process, fields []string) (result map[string]interface{}, err error) {
+	temp, err := json.Marshal(process)
+	if err != nil {
+		return result, err
+	}
+	err = json.Unmarshal(temp, &result)
+	if err != nil || fields == nil {
+		return result, err
+	}
+	for key := range result {
+		if !slices.Contains(fields, key) {
+			delete(result, key)
+		}
+	}
+	return result, nil
+}
+
+func processJsonKeys() (result []string) {
+	t := reflect.TypeOf(Process{})
+	for i := 0; i < t.NumField(); i++ {
+		result = append(result, strings.Split(t.Field(i).Tag.Get("json"), ",")[0])
+	}
+	return result
+}
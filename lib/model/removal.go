@@ -0,0 +1,38 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+type RemovalReason string
+
+const (
+	RemovalReasonDelete     RemovalReason = "delete"      //the process was moved to the trash
+	RemovalReasonUserDelete RemovalReason = "user_delete" //the only administrating user was deleted
+)
+
+// ProcessRemoval marks a process as removed but recoverable.
+// removed processes are hidden from lists and purged by the cleanup loop after Until.
+type ProcessRemoval struct {
+	Reason RemovalReason `json:"reason" bson:"reason"`
+	UserId string        `json:"user_id" bson:"user_id"` //user who removed the process or deleted user for RemovalReasonUserDelete
+	Date   int64         `json:"date" bson:"date"`       //unix
+	Until  int64         `json:"until" bson:"until"`     //unix; the process is purged afterward
+}
+
+// Expired checks if the process may be purged at now (unix)
+func (this ProcessRemoval) Expired(now int64) bool {
+	return this.Until <= now
+}
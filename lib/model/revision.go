@@ -0,0 +1,29 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+// AnyRevision may be used as expected revision to update a process regardless of its stored revision
+const AnyRevision int64 = -1
+
+// ProcessRevision is a snapshot of a Process as it was stored by one save
+type ProcessRevision struct {
+	ProcessId string  `json:"process_id" bson:"process_id"`
+	Revision  int64   `json:"revision" bson:"revision"`
+	UserId    string  `json:"user_id" bson:"user_id"`
+	Date      int64   `json:"date" bson:"date"`
+	Process   Process `json:"process" bson:"process"`
+}
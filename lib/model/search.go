@@ -0,0 +1,193 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// SearchScoreSort is the ListOptions.SortBy field which orders search results by relevance (descending); ties are sorted by name
+const SearchScoreSort = "score"
+
+// relevance weights of the searchable process fields
+const (
+	SearchWeightName        = 10
+	SearchWeightDescription = 5
+	SearchWeightTexts       = 1
+)
+
+// SearchSnippetRadius is the number of characters a highlight snippet contains before and after the first match
+const SearchSnippetRadius = 40
+
+// maxSearchHighlightsPerField limits the highlighted entries of list fields like ProcessMetadata.Texts
+const maxSearchHighlightsPerField = 3
+
+type SearchHighlight struct {
+	Field   string `json:"field"`   //name, description or texts
+	Snippet string `json:"snippet"` //html escaped excerpt; matched words are wrapped in <em></em>
+}
+
+// SearchWords splits the text into lower case words of letters and digits
+func SearchWords(text string) (result []string) {
+	runes := []rune(text)
+	for _, word := range searchWordRanges(runes) {
+		result = append(result, strings.ToLower(string(runes[word[0]:word[1]])))
+	}
+	return result
+}
+
+// SearchTerms returns the distinct words of a search string.
+// a process matches a search if every term equals a word of its name, description or ProcessMetadata.Texts.
+// a non-empty search without terms (e.g. only punctuation) matches nothing.
+func SearchTerms(search string) (result []string) {
+	known := map[string]bool{}
+	for _, word := range SearchWords(search) {
+		if !known[word] {
+			known[word] = true
+			result = append(result, word)
+		}
+	}
+	return result
+}
+
+// SearchScore returns the weighted count of term occurrences in the process, or 0 if not every term occurs
+func SearchScore(process Process, terms []string) (score float64) {
+	if len(terms) == 0 {
+		return 0
+	}
+	counts := map[string]int{}
+	for _, field := range []struct {
+		texts  []string
+		weight int
+	}{
+		{[]string{process.Name}, SearchWeightName},
+		{[]string{process.Description}, SearchWeightDescription},
+		{process.Metadata.Texts, SearchWeightTexts},
+	} {
+		for _, text := range field.texts {
+			for _, word := range SearchWords(text) {
+				counts[word] = counts[word] + field.weight
+			}
+		}
+	}
+	for _, term := range terms {
+		if counts[term] == 0 {
+			return 0
+		}
+		score = score + float64(counts[term])
+	}
+	return score
+}
+
+// HighlightSearchMatches returns snippets of the process fields containing at least one of the terms
+func HighlightSearchMatches(process Process, terms []string) (result []SearchHighlight) {
+	termSet := map[string]bool{}
+	for _, term := range terms {
+		termSet[term] = true
+	}
+	for _, field := range []struct {
+		name  string
+		texts []string
+	}{
+		{"name", []string{process.Name}},
+		{"description", []string{process.Description}},
+		{"texts", process.Metadata.Texts},
+	} {
+		count := 0
+		for _, text := range field.texts {
+			if count >= maxSearchHighlightsPerField {
+				break
+			}
+			if snippet, ok := highlightSnippet(text, termSet); ok {
+				result = append(result, SearchHighlight{Field: field.name, Snippet: snippet})
+				count++
+			}
+		}
+	}
+	return result
+}
+
+func highlightSnippet(text string, terms map[string]bool) (snippet string, ok bool) {
+	runes := []rune(text)
+	words := searchWordRanges(runes)
+	matches := [][2]int{}
+	for _, word := range words {
+		if terms[strings.ToLower(string(runes[word[0]:word[1]]))] {
+			matches = append(matches, word)
+		}
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+	//the snippet starts and ends with complete words
+	start, end := matches[0][0], matches[0][1]
+	for _, word := range words {
+		if word[0] < start && word[0] >= matches[0][0]-SearchSnippetRadius {
+			start = word[0]
+		}
+		if word[1] > end && word[1] <= matches[0][1]+SearchSnippetRadius {
+			end = word[1]
+		}
+	}
+	if matches[0][0] <= SearchSnippetRadius {
+		start = 0
+	}
+	if len(runes)-matches[0][1] <= SearchSnippetRadius {
+		end = len(runes)
+	}
+	builder := strings.Builder{}
+	if start > 0 {
+		builder.WriteString("…")
+	}
+	position := start
+	for _, match := range matches {
+		if match[0] < start || match[1] > end {
+			continue
+		}
+		builder.WriteString(html.EscapeString(string(runes[position:match[0]])))
+		builder.WriteString("<em>")
+		builder.WriteString(html.EscapeString(string(runes[match[0]:match[1]])))
+		builder.WriteString("</em>")
+		position = match[1]
+	}
+	builder.WriteString(html.EscapeString(string(runes[position:end])))
+	if end < len(runes) {
+		builder.WriteString("…")
+	}
+	return builder.String(), true
+}
+
+// searchWordRanges returns the [start, end) rune indexes of the words in text
+func searchWordRanges(text []rune) (result [][2]int) {
+	start := -1
+	for i, r := range text {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWordRune && start < 0 {
+			start = i
+		}
+		if !isWordRune && start >= 0 {
+			result = append(result, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		result = append(result, [2]int{start, len(text)})
+	}
+	return result
+}
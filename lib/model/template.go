@@ -0,0 +1,212 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/beevik/etree"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type TemplateParameterType string
+
+const (
+	TemplateParameterString   TemplateParameterType = "string"
+	TemplateParameterNumber   TemplateParameterType = "number"
+	TemplateParameterInteger  TemplateParameterType = "integer"
+	TemplateParameterBoolean  TemplateParameterType = "boolean"
+	TemplateParameterDuration TemplateParameterType = "duration" //ISO 8601 duration, e.g. PT5M
+)
+
+// ProcessTemplate is a process model with {{name}} placeholders in its bpmn and svg.
+// templates are readable by their owner and, if Publish is set, by every user.
+type ProcessTemplate struct {
+	Id              string              `json:"_id" bson:"_id"`
+	Name            string              `json:"name" bson:"name"`
+	Description     string              `json:"description" bson:"description"`
+	Owner           string              `json:"owner" bson:"owner"`
+	Publish         bool                `json:"publish" bson:"publish"`
+	BpmnXml         string              `json:"bpmn_xml" bson:"bpmn_xml"`
+	SvgXml          string              `json:"svgXML" bson:"svgXML"`
+	Parameters      []TemplateParameter `json:"parameters" bson:"parameters"`
+	LastUpdatedUnix int64               `json:"last_updated_unix" bson:"last_updated_unix"`
+}
+
+type TemplateParameter struct {
+	Name        string                `json:"name" bson:"name"`
+	Type        TemplateParameterType `json:"type" bson:"type"`
+	Description string                `json:"description" bson:"description"`
+	Default     interface{}           `json:"default,omitempty" bson:"default,omitempty"` //used if the instantiation provides no value; parameter is required if nil
+}
+
+// TemplateInstantiation is the request body to create a process from a template
+type TemplateInstantiation struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Parameters  map[string]interface{} `json:"parameters"`
+}
+
+type TemplateListOptions struct {
+	Owner            string //filter; ignored if empty
+	IncludePublished bool   //returns published templates of other owners additionally to the templates of Owner
+	Search           string
+	Limit            int64  //default 100
+	Offset           int64  //default 0
+	SortBy           string //default name.asc
+}
+
+var TemplatePlaceholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*\}\}`)
+var templateParameterNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+var durationPattern = regexp.MustCompile(`^P(\d+Y)?(\d+M)?(\d+W)?(\d+D)?(T(\d+H)?(\d+M)?(\d+(\.\d+)?S)?)?$`)
+
+func (template *ProcessTemplate) Validate() error {
+	if template.Id == "" {
+		return errors.New("missing id")
+	}
+	if template.Name == "" {
+		return errors.New("missing name")
+	}
+	declared := map[string]bool{}
+	for _, parameter := range template.Parameters {
+		if !templateParameterNamePattern.MatchString(parameter.Name) {
+			return fmt.Errorf("invalid parameter name '%v'", parameter.Name)
+		}
+		if declared[parameter.Name] {
+			return fmt.Errorf("duplicate parameter '%v'", parameter.Name)
+		}
+		declared[parameter.Name] = true
+		if !parameter.Type.IsValid() {
+			return fmt.Errorf("unknown type '%v' of parameter '%v'", parameter.Type, parameter.Name)
+		}
+		if parameter.Default != nil {
+			_, err := parameter.format(parameter.Default)
+			if err != nil {
+				return fmt.Errorf("invalid default of parameter '%v': %w", parameter.Name, err)
+			}
+		}
+	}
+	for _, placeholder := range template.Placeholders() {
+		if !declared[placeholder] {
+			return fmt.Errorf("placeholder '%v' is not declared as parameter", placeholder)
+		}
+	}
+	doc := etree.NewDocument()
+	err := doc.ReadFromString(template.BpmnXml)
+	if err != nil {
+		return err
+	}
+	if doc.FindElement("//bpmn:process") == nil {
+		return errors.New("missing process definition")
+	}
+	return nil
+}
+
+// Placeholders returns the distinct placeholder names used in the bpmn and svg of the template
+func (template *ProcessTemplate) Placeholders() (result []string) {
+	known := map[string]bool{}
+	for _, source := range []string{template.BpmnXml, template.SvgXml} {
+		for _, match := range TemplatePlaceholderPattern.FindAllStringSubmatch(source, -1) {
+			if !known[match[1]] {
+				known[match[1]] = true
+				result = append(result, match[1])
+			}
+		}
+	}
+	return result
+}
+
+// Apply replaces the placeholders of bpmn and svg with the xml escaped values.
+// values have to match the parameter types; parameters without value use their default.
+func (template *ProcessTemplate) Apply(values map[string]interface{}) (bpmn string, svg string, err error) {
+	replacements := map[string]string{}
+	declared := map[string]bool{}
+	for _, parameter := range template.Parameters {
+		declared[parameter.Name] = true
+		value, ok := values[parameter.Name]
+		if !ok || value == nil {
+			value = parameter.Default
+		}
+		formatted, err := parameter.format(value)
+		if err != nil {
+			return bpmn, svg, fmt.Errorf("parameter '%v': %w", parameter.Name, err)
+		}
+		buf := &bytes.Buffer{}
+		err = xml.EscapeText(buf, []byte(formatted))
+		if err != nil {
+			return bpmn, svg, err
+		}
+		replacements[parameter.Name] = buf.String()
+	}
+	for name := range values {
+		if !declared[name] {
+			return bpmn, svg, fmt.Errorf("unknown parameter '%v'", name)
+		}
+	}
+	replace := func(source string) string {
+		return TemplatePlaceholderPattern.ReplaceAllStringFunc(source, func(placeholder string) string {
+			name := TemplatePlaceholderPattern.FindStringSubmatch(placeholder)[1]
+			if replacement, ok := replacements[name]; ok {
+				return replacement
+			}
+			return placeholder
+		})
+	}
+	return replace(template.BpmnXml), replace(template.SvgXml), nil
+}
+
+func (this TemplateParameterType) IsValid() bool {
+	switch this {
+	case TemplateParameterString, TemplateParameterNumber, TemplateParameterInteger, TemplateParameterBoolean, TemplateParameterDuration:
+		return true
+	}
+	return false
+}
+
+func (parameter TemplateParameter) format(value interface{}) (string, error) {
+	if value == nil {
+		return "", errors.New("missing value")
+	}
+	switch parameter.Type {
+	case TemplateParameterString:
+		if str, ok := value.(string); ok {
+			return str, nil
+		}
+	case TemplateParameterNumber:
+		if f, ok := value.(float64); ok {
+			return strconv.FormatFloat(f, 'f', -1, 64), nil
+		}
+	case TemplateParameterInteger:
+		if f, ok := value.(float64); ok && f == math.Trunc(f) {
+			return strconv.FormatInt(int64(f), 10), nil
+		}
+	case TemplateParameterBoolean:
+		if b, ok := value.(bool); ok {
+			return strconv.FormatBool(b), nil
+		}
+	case TemplateParameterDuration:
+		if str, ok := value.(string); ok && durationPattern.MatchString(str) && str != "P" && !strings.HasSuffix(str, "T") {
+			return str, nil
+		}
+	}
+	return "", fmt.Errorf("expected value of type %v, got %#v", parameter.Type, value)
+}
@@ -0,0 +1,276 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"fmt"
+	"github.com/beevik/etree"
+	"log"
+	"runtime/debug"
+	"strings"
+)
+
+type ValidationSeverity string
+
+const (
+	SeverityError   ValidationSeverity = "error"
+	SeverityWarning ValidationSeverity = "warning"
+)
+
+type ValidationFinding struct {
+	ElementId string             `json:"element_id"`
+	Severity  ValidationSeverity `json:"severity"`
+	Message   string             `json:"message"`
+}
+
+// ValidationError is returned by Process.Validate if at least one finding has SeverityError.
+// Findings contains all findings, including warnings.
+type ValidationError struct {
+	Findings []ValidationFinding `json:"findings"`
+}
+
+func (this *ValidationError) Error() string {
+	messages := []string{}
+	for _, finding := range this.Findings {
+		if finding.Severity != SeverityError {
+			continue
+		}
+		if finding.ElementId != "" {
+			messages = append(messages, finding.ElementId+": "+finding.Message)
+		} else {
+			messages = append(messages, finding.Message)
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ValidationResult is the response of a dry-run validation
+type ValidationResult struct {
+	Valid    bool                `json:"valid"`
+	Name     string              `json:"name"`
+	Findings []ValidationFinding `json:"findings"`
+}
+
+func ContainsValidationErrors(findings []ValidationFinding) bool {
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+var flowNodeTags = map[string]bool{
+	"startEvent":             true,
+	"endEvent":               true,
+	"intermediateCatchEvent": true,
+	"intermediateThrowEvent": true,
+	"boundaryEvent":          true,
+	"task":                   true,
+	"userTask":               true,
+	"serviceTask":            true,
+	"scriptTask":             true,
+	"sendTask":               true,
+	"receiveTask":            true,
+	"manualTask":             true,
+	"businessRuleTask":       true,
+	"callActivity":           true,
+	"subProcess":             true,
+	"adHocSubProcess":        true,
+	"transaction":            true,
+	"exclusiveGateway":       true,
+	"parallelGateway":        true,
+	"inclusiveGateway":       true,
+	"eventBasedGateway":      true,
+	"complexGateway":         true,
+}
+
+var subProcessTags = map[string]bool{
+	"subProcess":  true,
+	"transaction": true,
+}
+
+// ValidateBpmn checks the structure of a bpmn xml and returns all findings.
+// it does not stop at the first error.
+func ValidateBpmn(bpmn string) (findings []ValidationFinding) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("%s: %s", r, debug.Stack())
+			findings = append(findings, ValidationFinding{Severity: SeverityError, Message: fmt.Sprint("Recovered Error: ", r)})
+		}
+	}()
+	findings = []ValidationFinding{}
+	doc := etree.NewDocument()
+	err := doc.ReadFromString(bpmn)
+	if err != nil {
+		return append(findings, ValidationFinding{Severity: SeverityError, Message: err.Error()})
+	}
+	findings = append(findings, validateUniqueIds(doc.Root())...)
+
+	processes := doc.FindElements("//bpmn:process")
+	if len(processes) == 0 {
+		return append(findings, ValidationFinding{Severity: SeverityError, Message: "missing process definition"})
+	}
+	for _, process := range processes {
+		id := process.SelectAttrValue("id", "")
+		if id == "" {
+			findings = append(findings, ValidationFinding{Severity: SeverityError, Message: "missing process definition id"})
+		}
+		startEventSeverity := SeverityWarning
+		if process.SelectAttrValue("isExecutable", "false") == "true" {
+			startEventSeverity = SeverityError
+		}
+		findings = append(findings, validateFlowScope(process, startEventSeverity)...)
+	}
+	return findings
+}
+
+func validateUniqueIds(root *etree.Element) (findings []ValidationFinding) {
+	if root == nil {
+		return []ValidationFinding{{Severity: SeverityError, Message: "missing root element"}}
+	}
+	known := map[string]bool{}
+	reported := map[string]bool{}
+	var walk func(element *etree.Element)
+	walk = func(element *etree.Element) {
+		id := element.SelectAttrValue("id", "")
+		if id != "" {
+			if known[id] && !reported[id] {
+				reported[id] = true
+				findings = append(findings, ValidationFinding{ElementId: id, Severity: SeverityError, Message: "duplicate element id"})
+			}
+			known[id] = true
+		}
+		for _, child := range element.ChildElements() {
+			walk(child)
+		}
+	}
+	walk(root)
+	return findings
+}
+
+// validateFlowScope checks the sequence flows and flow nodes that are direct children of scope (process or sub-process)
+// and descends into contained sub-processes
+func validateFlowScope(scope *etree.Element, startEventSeverity ValidationSeverity) (findings []ValidationFinding) {
+	nodes := map[string]*etree.Element{}
+	nodeOrder := []string{}
+	flows := []*etree.Element{}
+	for _, child := range scope.ChildElements() {
+		if child.Space != "bpmn" {
+			continue
+		}
+		if child.Tag == "sequenceFlow" {
+			flows = append(flows, child)
+			continue
+		}
+		if flowNodeTags[child.Tag] {
+			id := child.SelectAttrValue("id", "")
+			if id == "" {
+				findings = append(findings, ValidationFinding{Severity: SeverityError, Message: "missing id of " + child.Tag})
+				continue
+			}
+			if _, duplicate := nodes[id]; !duplicate {
+				nodeOrder = append(nodeOrder, id)
+			}
+			nodes[id] = child
+		}
+		if subProcessTags[child.Tag] {
+			findings = append(findings, validateFlowScope(child, SeverityError)...)
+		}
+	}
+
+	outgoing := map[string][]string{}
+	incomingCount := map[string]int{}
+	for _, flow := range flows {
+		flowId := flow.SelectAttrValue("id", "")
+		source := flow.SelectAttrValue("sourceRef", "")
+		target := flow.SelectAttrValue("targetRef", "")
+		if source == "" {
+			findings = append(findings, ValidationFinding{ElementId: flowId, Severity: SeverityError, Message: "sequence flow without sourceRef"})
+		} else if _, ok := nodes[source]; !ok {
+			findings = append(findings, ValidationFinding{ElementId: flowId, Severity: SeverityError, Message: "sequence flow references unknown sourceRef '" + source + "'"})
+		}
+		if target == "" {
+			findings = append(findings, ValidationFinding{ElementId: flowId, Severity: SeverityError, Message: "sequence flow without targetRef"})
+		} else if _, ok := nodes[target]; !ok {
+			findings = append(findings, ValidationFinding{ElementId: flowId, Severity: SeverityError, Message: "sequence flow references unknown targetRef '" + target + "'"})
+		}
+		if source != "" && target != "" {
+			outgoing[source] = append(outgoing[source], target)
+			incomingCount[target]++
+		}
+	}
+
+	if len(nodes) == 0 {
+		return findings
+	}
+
+	scopeId := scope.SelectAttrValue("id", "")
+	entries := []string{}
+	hasStartEvent := false
+	hasEndEvent := false
+	for _, id := range nodeOrder {
+		node := nodes[id]
+		switch {
+		case node.Tag == "startEvent":
+			hasStartEvent = true
+			entries = append(entries, id)
+		case node.Tag == "endEvent":
+			hasEndEvent = true
+		case node.Tag == "boundaryEvent":
+			entries = append(entries, id)
+		case node.SelectAttrValue("triggeredByEvent", "false") == "true":
+			entries = append(entries, id)
+		case node.SelectAttrValue("isForCompensation", "false") == "true":
+			entries = append(entries, id)
+		case node.Tag == "intermediateCatchEvent" && node.SelectElement("bpmn:linkEventDefinition") != nil:
+			entries = append(entries, id)
+		}
+	}
+	if !hasStartEvent {
+		findings = append(findings, ValidationFinding{ElementId: scopeId, Severity: startEventSeverity, Message: "missing start event"})
+	}
+	if !hasEndEvent {
+		findings = append(findings, ValidationFinding{ElementId: scopeId, Severity: SeverityWarning, Message: "missing end event"})
+	}
+
+	reachable := map[string]bool{}
+	for len(entries) > 0 {
+		current := entries[0]
+		entries = entries[1:]
+		if reachable[current] {
+			continue
+		}
+		reachable[current] = true
+		entries = append(entries, outgoing[current]...)
+	}
+	for _, id := range nodeOrder {
+		if !reachable[id] {
+			findings = append(findings, ValidationFinding{ElementId: id, Severity: SeverityWarning, Message: "element is not reachable from a start event"})
+		}
+		if strings.HasSuffix(nodes[id].Tag, "Gateway") {
+			out := len(outgoing[id])
+			switch {
+			case out == 0:
+				findings = append(findings, ValidationFinding{ElementId: id, Severity: SeverityWarning, Message: "gateway without outgoing sequence flow"})
+			case out == 1 && incomingCount[id] <= 1:
+				findings = append(findings, ValidationFinding{ElementId: id, Severity: SeverityWarning, Message: "gateway with a single outgoing sequence flow neither splits nor joins the flow"})
+			}
+		}
+	}
+	return findings
+}
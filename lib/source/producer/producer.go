@@ -0,0 +1,105 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-model-repository/lib/config"
+	"github.com/SENERGY-Platform/process-model-repository/lib/contextwg"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"github.com/SENERGY-Platform/process-model-repository/lib/source/util"
+	"github.com/segmentio/kafka-go"
+	"io"
+	"log"
+	"time"
+)
+
+const TIMEOUT = 10 * time.Second
+
+// Producer publishes model.ProcessChangeEvent messages to config.ProcessChangeTopic.
+// messages are keyed by the process id, so all events of a process keep their order.
+type Producer struct {
+	config config.Config
+	writer *kafka.Writer
+}
+
+func New(ctx context.Context, conf config.Config) (*Producer, error) {
+	if conf.InitTopics {
+		err := util.InitTopic(conf.KafkaUrl, conf.ProcessChangeTopic)
+		if err != nil {
+			log.Println("WARNING: unable to create topic", err)
+		}
+	}
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(conf.KafkaUrl),
+		Topic:                  conf.ProcessChangeTopic,
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		AllowAutoTopicCreation: true,
+		BatchTimeout:           10 * time.Millisecond,
+		Logger:                 log.New(io.Discard, "", 0),
+		ErrorLogger:            log.New(io.Discard, "", 0),
+	}
+	contextwg.Add(ctx, 1)
+	go func() {
+		defer contextwg.Done(ctx)
+		<-ctx.Done()
+		log.Println("close kafka writer:", conf.ProcessChangeTopic, writer.Close())
+	}()
+	return &Producer{config: conf, writer: writer}, nil
+}
+
+func (this *Producer) PublishProcessPut(id string, userId string, process model.Process) error {
+	summary := model.NewProcessChangeSummary(process)
+	return this.publish(model.ProcessChangeEvent{
+		Version:  model.ProcessChangeEventVersion,
+		Command:  model.ProcessChangePut,
+		Id:       id,
+		UserId:   userId,
+		Revision: process.Revision,
+		Time:     process.LastUpdatedUnix,
+		Process:  &summary,
+	})
+}
+
+func (this *Producer) PublishProcessDelete(id string, userId string, changeTime int64) error {
+	return this.publish(model.ProcessChangeEvent{
+		Version: model.ProcessChangeEventVersion,
+		Command: model.ProcessChangeDelete,
+		Id:      id,
+		UserId:  userId,
+		Time:    changeTime,
+	})
+}
+
+func (this *Producer) publish(event model.ProcessChangeEvent) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if this.config.Debug {
+		log.Println("DEBUG: produce", this.config.ProcessChangeTopic, event.Command, event.Id)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	defer cancel()
+	return this.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.Id),
+		Value: value,
+		Time:  time.Unix(event.Time, 0),
+	})
+}
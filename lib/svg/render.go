@@ -0,0 +1,437 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package svg
+
+import (
+	"errors"
+	"fmt"
+	"github.com/beevik/etree"
+	"log"
+	"math"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+const margin = 10.0
+const fontSize = 12.0
+const strokeColor = "#000000"
+const fillColor = "#ffffff"
+
+type bounds struct {
+	X, Y, Width, Height float64
+}
+
+type point struct {
+	X, Y float64
+}
+
+type shape struct {
+	element *etree.Element //referenced bpmn element, may be nil
+	tag     string
+	name    string
+	bounds  bounds
+	label   *bounds
+	expand  bool
+}
+
+type edge struct {
+	tag       string
+	name      string
+	waypoints []point
+	label     *bounds
+}
+
+// Render creates a svg image from the bpmn diagram interchange (BPMNShape and BPMNEdge elements) of a bpmn xml
+func Render(bpmn string) (result string, err error) {
+	defer func() {
+		if r := recover(); r != nil && err == nil {
+			log.Printf("%s: %s", r, debug.Stack())
+			err = errors.New(fmt.Sprint("Recovered Error: ", r))
+		}
+	}()
+	doc := etree.NewDocument()
+	err = doc.ReadFromString(bpmn)
+	if err != nil {
+		return "", err
+	}
+	elements := map[string]*etree.Element{}
+	for _, element := range doc.FindElements("//[@id]") {
+		if element.Space == "bpmn" {
+			elements[element.SelectAttrValue("id", "")] = element
+		}
+	}
+
+	shapes := []shape{}
+	for _, element := range doc.FindElements("//bpmndi:BPMNShape") {
+		s, ok := readShape(element, elements)
+		if ok {
+			shapes = append(shapes, s)
+		}
+	}
+	edges := []edge{}
+	for _, element := range doc.FindElements("//bpmndi:BPMNEdge") {
+		e, ok := readEdge(element, elements)
+		if ok {
+			edges = append(edges, e)
+		}
+	}
+	if len(shapes) == 0 && len(edges) == 0 {
+		return "", errors.New("missing bpmn diagram interchange")
+	}
+
+	view := viewBox(shapes, edges)
+	out := etree.NewDocument()
+	root := out.CreateElement("svg")
+	root.CreateAttr("xmlns", "http://www.w3.org/2000/svg")
+	root.CreateAttr("width", format(view.Width))
+	root.CreateAttr("height", format(view.Height))
+	root.CreateAttr("viewBox", format(view.X)+" "+format(view.Y)+" "+format(view.Width)+" "+format(view.Height))
+	createMarker(root)
+
+	//containers first, so that contained elements and edges are drawn on top
+	for _, s := range shapes {
+		if isContainer(s) {
+			drawShape(root, s)
+		}
+	}
+	for _, e := range edges {
+		drawEdge(root, e)
+	}
+	for _, s := range shapes {
+		if !isContainer(s) {
+			drawShape(root, s)
+		}
+	}
+
+	out.Indent(2)
+	return out.WriteToString()
+}
+
+func readShape(element *etree.Element, elements map[string]*etree.Element) (result shape, ok bool) {
+	b, ok := readBounds(element.SelectElement("dc:Bounds"))
+	if !ok {
+		return result, false
+	}
+	result.bounds = b
+	result.element = elements[element.SelectAttrValue("bpmnElement", "")]
+	if result.element != nil {
+		result.tag = result.element.Tag
+		result.name = result.element.SelectAttrValue("name", "")
+		if result.tag == "textAnnotation" {
+			if text := result.element.SelectElement("bpmn:text"); text != nil {
+				result.name = text.Text()
+			}
+		}
+	}
+	result.expand = element.SelectAttrValue("isExpanded", "false") == "true"
+	if label := element.SelectElement("bpmndi:BPMNLabel"); label != nil {
+		if lb, ok := readBounds(label.SelectElement("dc:Bounds")); ok {
+			result.label = &lb
+		}
+	}
+	return result, true
+}
+
+func readEdge(element *etree.Element, elements map[string]*etree.Element) (result edge, ok bool) {
+	for _, waypoint := range element.SelectElements("di:waypoint") {
+		x, errX := strconv.ParseFloat(waypoint.SelectAttrValue("x", ""), 64)
+		y, errY := strconv.ParseFloat(waypoint.SelectAttrValue("y", ""), 64)
+		if errX == nil && errY == nil {
+			result.waypoints = append(result.waypoints, point{X: x, Y: y})
+		}
+	}
+	if len(result.waypoints) < 2 {
+		return result, false
+	}
+	if bpmnElement := elements[element.SelectAttrValue("bpmnElement", "")]; bpmnElement != nil {
+		result.tag = bpmnElement.Tag
+		result.name = bpmnElement.SelectAttrValue("name", "")
+	}
+	if label := element.SelectElement("bpmndi:BPMNLabel"); label != nil {
+		if lb, ok := readBounds(label.SelectElement("dc:Bounds")); ok {
+			result.label = &lb
+		}
+	}
+	return result, true
+}
+
+func readBounds(element *etree.Element) (result bounds, ok bool) {
+	if element == nil {
+		return result, false
+	}
+	values := []*float64{&result.X, &result.Y, &result.Width, &result.Height}
+	for i, key := range []string{"x", "y", "width", "height"} {
+		value, err := strconv.ParseFloat(element.SelectAttrValue(key, ""), 64)
+		if err != nil {
+			return result, false
+		}
+		*values[i] = value
+	}
+	return result, true
+}
+
+func viewBox(shapes []shape, edges []edge) bounds {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	extend := func(b bounds) {
+		minX = math.Min(minX, b.X)
+		minY = math.Min(minY, b.Y)
+		maxX = math.Max(maxX, b.X+b.Width)
+		maxY = math.Max(maxY, b.Y+b.Height)
+	}
+	for _, s := range shapes {
+		extend(s.bounds)
+		if s.label != nil {
+			extend(*s.label)
+		}
+	}
+	for _, e := range edges {
+		for _, p := range e.waypoints {
+			extend(bounds{X: p.X, Y: p.Y})
+		}
+		if e.label != nil {
+			extend(*e.label)
+		}
+	}
+	return bounds{X: minX - margin, Y: minY - margin, Width: maxX - minX + 2*margin, Height: maxY - minY + 2*margin}
+}
+
+func isContainer(s shape) bool {
+	switch s.tag {
+	case "participant", "lane":
+		return true
+	case "subProcess", "transaction", "adHocSubProcess":
+		return s.expand
+	}
+	return false
+}
+
+func createMarker(root *etree.Element) {
+	defs := root.CreateElement("defs")
+	marker := defs.CreateElement("marker")
+	marker.CreateAttr("id", "sequenceflow-end")
+	marker.CreateAttr("viewBox", "0 0 20 20")
+	marker.CreateAttr("refX", "11")
+	marker.CreateAttr("refY", "10")
+	marker.CreateAttr("markerWidth", "10")
+	marker.CreateAttr("markerHeight", "10")
+	marker.CreateAttr("orient", "auto")
+	path := marker.CreateElement("path")
+	path.CreateAttr("d", "M 1 5 L 11 10 L 1 15 Z")
+	path.CreateAttr("fill", strokeColor)
+	path.CreateAttr("stroke", strokeColor)
+}
+
+func drawShape(root *etree.Element, s shape) {
+	g := root.CreateElement("g")
+	if s.element != nil {
+		g.CreateAttr("data-element-id", s.element.SelectAttrValue("id", ""))
+	}
+	b := s.bounds
+	switch {
+	case strings.HasSuffix(s.tag, "Event"):
+		circle := g.CreateElement("circle")
+		circle.CreateAttr("cx", format(b.X+b.Width/2))
+		circle.CreateAttr("cy", format(b.Y+b.Height/2))
+		circle.CreateAttr("r", format(math.Min(b.Width, b.Height)/2))
+		strokeWidth := 2.0
+		if s.tag == "endEvent" {
+			strokeWidth = 4
+		}
+		style(circle, fillColor, strokeWidth, "")
+		if s.tag == "intermediateCatchEvent" || s.tag == "intermediateThrowEvent" || s.tag == "boundaryEvent" {
+			inner := g.CreateElement("circle")
+			inner.CreateAttr("cx", format(b.X+b.Width/2))
+			inner.CreateAttr("cy", format(b.Y+b.Height/2))
+			inner.CreateAttr("r", format(math.Min(b.Width, b.Height)/2-3))
+			style(inner, "none", 1, "")
+		}
+		drawOuterLabel(g, s)
+	case strings.HasSuffix(s.tag, "Gateway"):
+		diamond := g.CreateElement("polygon")
+		diamond.CreateAttr("points", formatPoints([]point{
+			{X: b.X + b.Width/2, Y: b.Y},
+			{X: b.X + b.Width, Y: b.Y + b.Height/2},
+			{X: b.X + b.Width/2, Y: b.Y + b.Height},
+			{X: b.X, Y: b.Y + b.Height/2},
+		}))
+		style(diamond, fillColor, 2, "")
+		drawOuterLabel(g, s)
+	case s.tag == "participant" || s.tag == "lane":
+		rect := createRect(g, b, 0)
+		style(rect, "none", 1.5, "")
+		drawVerticalText(g, s.name, bounds{X: b.X, Y: b.Y, Width: 30, Height: b.Height})
+	case s.tag == "textAnnotation":
+		path := g.CreateElement("path")
+		path.CreateAttr("d", "M "+format(b.X+10)+" "+format(b.Y)+" L "+format(b.X)+" "+format(b.Y)+" L "+format(b.X)+" "+format(b.Y+b.Height)+" L "+format(b.X+10)+" "+format(b.Y+b.Height))
+		style(path, "none", 1, "")
+		drawText(g, s.name, bounds{X: b.X + 5, Y: b.Y, Width: b.Width - 5, Height: b.Height}, "start")
+	case s.tag == "dataObjectReference" || s.tag == "dataStoreReference" || s.tag == "dataInput" || s.tag == "dataOutput":
+		rect := createRect(g, b, 0)
+		style(rect, fillColor, 1.5, "")
+		drawOuterLabel(g, s)
+	default:
+		rect := createRect(g, b, 10)
+		strokeWidth := 2.0
+		if s.tag == "callActivity" {
+			strokeWidth = 5
+		}
+		style(rect, fillColor, strokeWidth, "")
+		if isContainer(s) {
+			rect.RemoveAttr("fill")
+			rect.CreateAttr("fill", "none")
+			drawText(g, s.name, bounds{X: b.X, Y: b.Y, Width: b.Width, Height: 2 * fontSize}, "middle")
+		} else {
+			drawText(g, s.name, b, "middle")
+		}
+	}
+}
+
+func drawEdge(root *etree.Element, e edge) {
+	g := root.CreateElement("g")
+	line := g.CreateElement("polyline")
+	line.CreateAttr("points", formatPoints(e.waypoints))
+	switch e.tag {
+	case "messageFlow":
+		style(line, "none", 1.5, "10,6")
+		line.CreateAttr("marker-end", "url(#sequenceflow-end)")
+	case "association":
+		style(line, "none", 1.5, "0.5,5")
+		line.CreateAttr("stroke-linecap", "round")
+	case "dataInputAssociation", "dataOutputAssociation":
+		style(line, "none", 1.5, "0.5,5")
+		line.CreateAttr("stroke-linecap", "round")
+		line.CreateAttr("marker-end", "url(#sequenceflow-end)")
+	default:
+		style(line, "none", 2, "")
+		line.CreateAttr("marker-end", "url(#sequenceflow-end)")
+	}
+	if e.name != "" {
+		labelBounds := bounds{X: e.waypoints[0].X, Y: e.waypoints[0].Y, Width: 90, Height: 2 * fontSize}
+		if e.label != nil {
+			labelBounds = *e.label
+		}
+		drawText(g, e.name, labelBounds, "middle")
+	}
+}
+
+func drawOuterLabel(g *etree.Element, s shape) {
+	if s.name == "" {
+		return
+	}
+	b := bounds{X: s.bounds.X - 25, Y: s.bounds.Y + s.bounds.Height + 5, Width: s.bounds.Width + 50, Height: 2 * fontSize}
+	if s.label != nil {
+		b = *s.label
+	}
+	drawText(g, s.name, b, "middle")
+}
+
+func createRect(g *etree.Element, b bounds, radius float64) *etree.Element {
+	rect := g.CreateElement("rect")
+	rect.CreateAttr("x", format(b.X))
+	rect.CreateAttr("y", format(b.Y))
+	rect.CreateAttr("width", format(b.Width))
+	rect.CreateAttr("height", format(b.Height))
+	if radius > 0 {
+		rect.CreateAttr("rx", format(radius))
+		rect.CreateAttr("ry", format(radius))
+	}
+	return rect
+}
+
+func style(element *etree.Element, fill string, strokeWidth float64, dash string) {
+	element.CreateAttr("fill", fill)
+	element.CreateAttr("stroke", strokeColor)
+	element.CreateAttr("stroke-width", format(strokeWidth))
+	if dash != "" {
+		element.CreateAttr("stroke-dasharray", dash)
+	}
+}
+
+// drawText writes text word wrapped and vertically centered into b
+func drawText(g *etree.Element, text string, b bounds, anchor string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	lines := wrap(text, int(math.Max(1, b.Width/(fontSize*0.6))))
+	x := b.X + b.Width/2
+	if anchor == "start" {
+		x = b.X
+	}
+	y := b.Y + b.Height/2 - float64(len(lines)-1)*fontSize*1.2/2 + fontSize/3
+	element := g.CreateElement("text")
+	element.CreateAttr("font-family", "Arial, sans-serif")
+	element.CreateAttr("font-size", format(fontSize)+"px")
+	element.CreateAttr("text-anchor", anchor)
+	for i, line := range lines {
+		span := element.CreateElement("tspan")
+		span.CreateAttr("x", format(x))
+		span.CreateAttr("y", format(y+float64(i)*fontSize*1.2))
+		span.SetText(line)
+	}
+}
+
+func drawVerticalText(g *etree.Element, text string, b bounds) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	x := b.X + b.Width/2
+	y := b.Y + b.Height/2
+	element := g.CreateElement("text")
+	element.CreateAttr("font-family", "Arial, sans-serif")
+	element.CreateAttr("font-size", format(fontSize)+"px")
+	element.CreateAttr("text-anchor", "middle")
+	element.CreateAttr("x", format(x))
+	element.CreateAttr("y", format(y))
+	element.CreateAttr("transform", "rotate(-90 "+format(x)+" "+format(y)+")")
+	element.SetText(text)
+}
+
+func wrap(text string, maxLineLength int) (lines []string) {
+	current := ""
+	for _, word := range strings.Fields(text) {
+		if current == "" {
+			current = word
+			continue
+		}
+		if len(current)+1+len(word) > maxLineLength {
+			lines = append(lines, current)
+			current = word
+			continue
+		}
+		current = current + " " + word
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+func formatPoints(points []point) string {
+	result := []string{}
+	for _, p := range points {
+		result = append(result, format(p.X)+","+format(p.Y))
+	}
+	return strings.Join(result, " ")
+}
+
+func format(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,264 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-model-repository/lib"
+	"github.com/SENERGY-Platform/process-model-repository/lib/config"
+	"github.com/SENERGY-Platform/process-model-repository/lib/contextwg"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+)
+
+func TestBpmnFiles(t *testing.T) {
+	conf, err := config.Load("../config.json")
+	if err != nil {
+		log.Fatal("ERROR: unable to load config", err)
+	}
+	conf.Debug = true
+	conf.ConnectivityTest = false
+
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctx = contextwg.WithWaitGroup(ctx, wg)
+
+	_, mongoIp, err := MongoTestServer(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	conf.MongoUrl = "mongodb://" + mongoIp + ":27017"
+
+	conf.KafkaUrl, err = Kafka(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	time.Sleep(2 * time.Second)
+
+	_, permIp, err := PermissionsV2(ctx, wg, conf.MongoUrl, conf.KafkaUrl)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	conf.PermissionsV2Url = "http://" + permIp + ":8080"
+
+	port, err := getFreePort()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	conf.ServerPort = strconv.Itoa(port)
+
+	err = lib.Start(ctx, conf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	time.Sleep(2 * time.Second)
+
+	var p model.Process
+	t.Run("import", func(t *testing.T) {
+		resp, err := Post(userjwt1, "http://localhost:"+conf.ServerPort+"/v2/processes/import", "application/xml", strings.NewReader(createTestXmlString("imported")))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer resp.Body.Close()
+		err = json.NewDecoder(resp.Body).Decode(&p)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if p.Name != "imported" || p.BpmnXml != createTestXmlString("imported") || p.SvgXml == "" {
+			t.Errorf("%#v", p)
+		}
+	})
+
+	t.Run("download", func(t *testing.T) {
+		resp, err := Get(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/bpmn")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer resp.Body.Close()
+		if disposition := resp.Header.Get("Content-Disposition"); disposition != `attachment; filename=imported.bpmn` {
+			t.Error(disposition)
+		}
+		if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "application/xml") {
+			t.Error(contentType)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if string(body) != createTestXmlString("imported") {
+			t.Error(string(body))
+		}
+	})
+
+	t.Run("svg", func(t *testing.T) {
+		resp, err := Get(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/svg")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer resp.Body.Close()
+		if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "image/svg+xml") {
+			t.Error(contentType)
+		}
+		if csp := resp.Header.Get("Content-Security-Policy"); csp != "default-src 'none'; style-src 'unsafe-inline'" {
+			t.Error(csp)
+		}
+		if nosniff := resp.Header.Get("X-Content-Type-Options"); nosniff != "nosniff" {
+			t.Error(nosniff)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if string(body) != p.SvgXml {
+			t.Error(string(body))
+		}
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		var copied model.Process
+		err := PostJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/copy", nil, &copied)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if copied.Id == "" || copied.Id == p.Id || copied.Name != p.Name || copied.Revision != 1 {
+			t.Errorf("%#v", copied)
+		}
+		if strings.Contains(copied.BpmnXml, `"imported"`) || !strings.Contains(copied.BpmnXml, `<bpmn:process id="Process_`) {
+			t.Error(copied.BpmnXml)
+		}
+	})
+
+	t.Run("copy without access", func(t *testing.T) {
+		var copied model.Process
+		err := PostJSON(userjwt2, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/copy", nil, &copied)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("import invalid", func(t *testing.T) {
+		_, err := Post(userjwt1, "http://localhost:"+conf.ServerPort+"/v2/processes/import", "application/xml", strings.NewReader("<bpmn:definitions"))
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("label and publish", func(t *testing.T) {
+		update := p
+		update.Tags = []string{"t1"}
+		update.Folder = "team"
+		err := PutJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id, update, &p)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = PostJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/publish", model.PublicCommand{Publish: true, Description: "d", Categories: []string{"finance"}}, &p)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	})
+
+	t.Run("export archive", func(t *testing.T) {
+		resp, err := Get(userjwt1, "http://localhost:"+conf.ServerPort+"/v2/processes/archive")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer resp.Body.Close()
+		archive, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		names := []string{}
+		for _, f := range reader.File {
+			names = append(names, f.Name)
+		}
+		expected := []string{p.Id + ".bpmn", p.Id + ".svg", p.Id + ".json"}
+		if !reflect.DeepEqual(names, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", names, expected)
+			return
+		}
+
+		t.Run("import archive as other user", func(t *testing.T) {
+			resp, err := Post(userjwt2, "http://localhost:"+conf.ServerPort+"/v2/processes/archive", "application/zip", bytes.NewReader(archive))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer resp.Body.Close()
+			result := []model.ArchiveImportResult{}
+			err = json.NewDecoder(resp.Body).Decode(&result)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if len(result) != 1 || result[0].Error != "" || result[0].ProcessId == "" || result[0].ProcessId == p.Id || result[0].Name != "imported" {
+				t.Errorf("%#v", result)
+				return
+			}
+			imported := model.Process{}
+			err = GetJSON(userjwt2, "http://localhost:"+conf.ServerPort+"/processes/"+result[0].ProcessId, &imported)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if imported.BpmnXml != p.BpmnXml || imported.SvgXml != p.SvgXml || imported.Owner != userid2 {
+				t.Errorf("%#v", imported)
+			}
+			if !imported.Publish || imported.Description != "d" || !reflect.DeepEqual(imported.Categories, []string{"finance"}) || !reflect.DeepEqual(imported.Tags, []string{"t1"}) || imported.Folder != "team" {
+				t.Errorf("%#v", imported)
+			}
+		})
+	})
+}
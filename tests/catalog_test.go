@@ -0,0 +1,59 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+)
+
+func TestNormalizeCategories(t *testing.T) {
+	t.Run("normalize", func(t *testing.T) {
+		actual, err := model.NormalizeCategories([]string{" logistics", "finance", "logistics "})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := []string{"finance", "logistics"}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", actual, expected)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		actual, err := model.NormalizeCategories(nil)
+		if err != nil || !reflect.DeepEqual(actual, []string{}) {
+			t.Errorf("%#v %v", actual, err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tooMany := []string{}
+		for i := 0; i <= model.MaxCategories; i++ {
+			tooMany = append(tooMany, strings.Repeat("c", i+1))
+		}
+		for _, categories := range [][]string{{" "}, {strings.Repeat("c", model.MaxCategoryLength+1)}, tooMany} {
+			_, err := model.NormalizeCategories(categories)
+			if err == nil {
+				t.Errorf("expected error for %#v", categories)
+			}
+		}
+	})
+}
@@ -109,6 +109,19 @@ func TestMongoCheckIdList(t *testing.T) {
 			return
 		}
 	})
+	t.Run("create removed entry without idList entry", func(t *testing.T) {
+		id := "removed-process-missing-in-list"
+		err = db.SetProcess(ctx, model.Process{
+			Id:              id,
+			Name:            id,
+			LastUpdatedUnix: 1700000000,
+			Removal:         &model.ProcessRemoval{Reason: model.RemovalReasonDelete, Date: 1700000000, Until: 1700000001},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	})
 	t.Run("create legacy entry with idList entry", func(t *testing.T) {
 		id := "legacy-process"
 		idList = append(idList, id)
@@ -282,6 +295,7 @@ func TestCleanup(t *testing.T) {
 				t.Error(err)
 				return
 			}
+			expected := processSummaries(expected)
 			if !reflect.DeepEqual(actual, expected) {
 				t.Errorf("\n%#v\n%#v\n", actual, expected)
 				return
@@ -311,20 +325,149 @@ func TestCleanup(t *testing.T) {
 		}
 	})
 
+	t.Run("move p4 into trash", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodDelete, "http://localhost:"+conf.ServerPort+"/processes/"+p4.Id, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		req.Header.Set("Authorization", userjwt1)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Error(resp.StatusCode)
+		}
+		time.Sleep(time.Second)
+	})
+
+	t.Run("cleanup aborted by safety threshold", func(t *testing.T) {
+		report, err := ctrl.Cleanup(false, 25, 0)
+		if err == nil || report.Applied || report.AbortReason == "" {
+			t.Errorf("%#v %v", report, err)
+		}
+		expectedReason := "1 of 3 permissions would be removed; 1 of 3 processes would be removed"
+		if report.AbortReason != expectedReason {
+			t.Errorf("\na=%#v\ne=%#v\n", report.AbortReason, expectedReason)
+		}
+	})
+
+	t.Run("cleanup below safety min count", func(t *testing.T) {
+		report, err := ctrl.Cleanup(true, 25, 1)
+		if err != nil || report.AbortReason != "" {
+			t.Errorf("%#v %v", report, err)
+		}
+	})
+
+	t.Run("cleanup dry run", func(t *testing.T) {
+		report, err := ctrl.Cleanup(true, 0, 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if report.Applied || !reflect.DeepEqual(report.OrphanedProcesses, []string{p2.Id}) || !reflect.DeepEqual(report.OrphanedResources, []string{p3.Id}) || report.ProcessCount != 3 || report.ResourceCount != 3 {
+			t.Errorf("%#v", report)
+		}
+		_, exists, err := db.ReadProcess(ctx, p2.Id)
+		if err != nil || !exists {
+			t.Error(exists, err)
+		}
+		last := model.CleanupReport{}
+		err = GetJSON(client.InternalAdminToken, "http://localhost:"+conf.ServerPort+"/admin/cleanup/report", &last)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !reflect.DeepEqual(last, report) {
+			t.Errorf("\na=%#v\ne=%#v\n", last, report)
+		}
+		_, err = Get(userjwt1, "http://localhost:"+conf.ServerPort+"/admin/cleanup/report")
+		if err == nil {
+			t.Error("expected error for non admin")
+		}
+	})
+
 	t.Run("call cleanup", func(t *testing.T) {
-		removedPermissions, removedProcesses, err := ctrl.Cleanup()
+		report, err := ctrl.Cleanup(false, 0, 0)
 		if err != nil {
 			t.Error(err)
 		}
-		if removedProcesses != 1 {
-			t.Errorf("\na=%#v\ne=%#v\n", removedProcesses, 1)
+		if !report.Applied {
+			t.Errorf("%#v", report)
 		}
-		if removedPermissions != 1 {
-			t.Errorf("\na=%#v\ne=%#v\n", removedPermissions, 1)
+		if len(report.OrphanedProcesses) != 1 {
+			t.Errorf("\na=%#v\ne=%#v\n", len(report.OrphanedProcesses), 1)
+		}
+		if len(report.OrphanedResources) != 1 {
+			t.Errorf("\na=%#v\ne=%#v\n", len(report.OrphanedResources), 1)
 		}
 	})
 
 	t.Run("list after cleanup", func(t *testing.T) {
-		testList(userjwt1, "/v2/processes", []model.Process{p1, p4})
+		testList(userjwt1, "/v2/processes", []model.Process{p1})
+	})
+
+	t.Run("trash kept by cleanup", func(t *testing.T) {
+		trash := []model.Process{}
+		err := GetJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/v2/processes/trash", &trash)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(trash) != 1 || trash[0].Id != p4.Id || trash[0].Removal == nil || trash[0].Removal.Reason != model.RemovalReasonDelete {
+			t.Errorf("%#v", trash)
+			return
+		}
+		list := []model.Process{}
+		err = GetJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/v2/processes", &list)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(list) != 1 || list[0].Id != p1.Id {
+			t.Errorf("%#v", list)
+		}
+	})
+
+	t.Run("restore p4", func(t *testing.T) {
+		restored := model.Process{}
+		err := PostJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p4.Id+"/restore", nil, &restored)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if restored.Removal != nil || restored.Revision != p4.Revision+2 {
+			t.Errorf("%#v", restored)
+		}
+	})
+
+	t.Run("purge expired trash", func(t *testing.T) {
+		process, _, err := db.ReadProcess(ctx, p1.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		process.Removal = &model.ProcessRemoval{Reason: model.RemovalReasonDelete, UserId: userid1, Date: 1, Until: 2}
+		err = db.SetProcess(ctx, process)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		purged, err := ctrl.PurgeRemovedProcesses()
+		if err != nil || purged != 1 {
+			t.Error(purged, err)
+			return
+		}
+		_, exists, err := db.ReadProcess(ctx, p1.Id)
+		if err != nil || exists {
+			t.Error(exists, err)
+		}
+		_, exists, err = db.ReadProcess(ctx, p4.Id)
+		if err != nil || !exists {
+			t.Error(exists, err)
+		}
 	})
 }
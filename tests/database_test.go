@@ -0,0 +1,524 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-model-repository/lib/config"
+	"github.com/SENERGY-Platform/process-model-repository/lib/contextwg"
+	"github.com/SENERGY-Platform/process-model-repository/lib/database"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+)
+
+func TestMemoryDatabase(t *testing.T) {
+	conf, err := config.Load("../config.json")
+	if err != nil {
+		log.Fatal("ERROR: unable to load config", err)
+	}
+	conf.DatabaseType = "memory"
+	db, err := database.New(context.Background(), conf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	testDatabaseContract(t, db)
+}
+
+func TestMongoDatabase(t *testing.T) {
+	conf, err := config.Load("../config.json")
+	if err != nil {
+		log.Fatal("ERROR: unable to load config", err)
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = contextwg.WithWaitGroup(ctx, wg)
+
+	_, mongoIp, err := MongoTestServer(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	conf.MongoUrl = "mongodb://" + mongoIp + ":27017"
+	conf.DatabaseType = "mongo"
+	db, err := database.New(ctx, conf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	testDatabaseContract(t, db)
+}
+
+func TestPostgresDatabase(t *testing.T) {
+	conf, err := config.Load("../config.json")
+	if err != nil {
+		log.Fatal("ERROR: unable to load config", err)
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = contextwg.WithWaitGroup(ctx, wg)
+
+	_, postgresIp, err := PostgresTestServer(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	conf.PostgresUrl = "postgres://postgres:postgres@" + postgresIp + ":5432/process_repository"
+	conf.DatabaseType = "postgres"
+	db, err := database.New(ctx, conf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	testDatabaseContract(t, db)
+}
+
+// testDatabaseContract checks the semantics every database.Database implementation has to provide.
+// db has to be empty.
+func testDatabaseContract(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	old := time.Now().Add(-time.Hour).Unix()
+	processes := []model.Process{
+		{Id: "p1", Name: "a 1", Description: "x", Revision: 1, LastUpdatedUnix: old, BpmnXml: "bpmn1", Tags: []string{"t-a"}, Folder: "team", Metadata: model.ProcessMetadata{TaskCount: 1, Topics: []string{"t1"}}},
+		{Id: "p2", Name: "b 1", Description: "y", Revision: 1, LastUpdatedUnix: old, Publish: true, Categories: []string{"finance", "logistics"}, Tags: []string{"t-a", "t-b"}, Folder: "team/sub", Metadata: model.ProcessMetadata{TaskCount: 3, Topics: []string{"t1", "t2"}, Timers: []model.TimerDefinition{{ElementId: "e", Type: "timeCycle", Value: "R/PT1H"}}, Texts: []string{"check stock"}}},
+		{Id: "p3", Name: "A 2", Description: "y", Revision: 1, LastUpdatedUnix: old, Folder: "teams", Metadata: model.ProcessMetadata{TaskCount: 5, Messages: []string{"m"}}},
+		{Id: "p4", Name: "b 2", Description: "X stock", Revision: 1, Categories: []string{"logistics"}, Metadata: model.ProcessMetadata{Lanes: []string{"l"}}},
+	}
+
+	t.Run("set processes", func(t *testing.T) {
+		for _, process := range processes {
+			ok, err := db.SetProcessIfRevision(ctx, process, 0)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !ok {
+				t.Error(process.Id)
+			}
+		}
+	})
+
+	t.Run("read process", func(t *testing.T) {
+		actual, exists, err := db.ReadProcess(ctx, "p1")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !exists || !reflect.DeepEqual(actual, processes[0]) {
+			t.Errorf("\na=%#v\ne=%#v\n", actual, processes[0])
+		}
+		_, exists, err = db.ReadProcess(ctx, "unknown")
+		if err != nil || exists {
+			t.Error(exists, err)
+		}
+	})
+
+	t.Run("set if revision", func(t *testing.T) {
+		update := processes[0]
+		update.Revision = 2
+		ok, err := db.SetProcessIfRevision(ctx, update, 0)
+		if err != nil || ok {
+			t.Error("existing process replaced as new process", ok, err)
+		}
+		ok, err = db.SetProcessIfRevision(ctx, update, 5)
+		if err != nil || ok {
+			t.Error("process replaced with wrong revision", ok, err)
+		}
+		ok, err = db.SetProcessIfRevision(ctx, model.Process{Id: "unknown", Revision: 2}, 1)
+		if err != nil || ok {
+			t.Error("missing process created with revision != 0", ok, err)
+		}
+		ok, err = db.SetProcessIfRevision(ctx, update, 1)
+		if err != nil || !ok {
+			t.Error("unable to update", ok, err)
+		}
+		processes[0] = update
+	})
+
+	testList := func(options model.ListOptions, expectedIds []string, expectedTotal int64) func(t *testing.T) {
+		return func(t *testing.T) {
+			actual, total, err := db.ListProcesses(ctx, options)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			actualIds := []string{}
+			for _, process := range actual {
+				actualIds = append(actualIds, process.Id)
+			}
+			if !reflect.DeepEqual(actualIds, expectedIds) || total != expectedTotal {
+				t.Errorf("\na=%#v %v\ne=%#v %v\n", actualIds, total, expectedIds, expectedTotal)
+			}
+		}
+	}
+	t.Run("list default sort", testList(model.ListOptions{}, []string{"p3", "p1", "p2", "p4"}, 4))
+	t.Run("list sort desc", testList(model.ListOptions{SortBy: "name.desc"}, []string{"p4", "p2", "p1", "p3"}, 4))
+	t.Run("list sort by number", testList(model.ListOptions{SortBy: "revision.desc", Limit: 1}, []string{"p1"}, 4))
+	t.Run("list limit offset", testList(model.ListOptions{Limit: 2, Offset: 1}, []string{"p1", "p2"}, 4))
+	t.Run("list ids", testList(model.ListOptions{Ids: []string{"p4", "p1", "unknown"}}, []string{"p1", "p4"}, 2))
+	t.Run("list empty ids", testList(model.ListOptions{Ids: []string{}}, []string{}, 0))
+	t.Run("list search name", testList(model.ListOptions{Search: "a"}, []string{"p3", "p1"}, 2))
+	t.Run("list search description", testList(model.ListOptions{Search: "x"}, []string{"p1", "p4"}, 2))
+	t.Run("list search special chars", testList(model.ListOptions{Search: "a.*"}, []string{"p3", "p1"}, 2))
+	t.Run("list search without words", testList(model.ListOptions{Search: ".*"}, []string{}, 0))
+	t.Run("list search words", testList(model.ListOptions{Search: "b 2"}, []string{"p4"}, 1))
+	t.Run("list search no substring", testList(model.ListOptions{Search: "sto"}, []string{}, 0))
+	t.Run("list search texts", testList(model.ListOptions{Search: "check"}, []string{"p2"}, 1))
+	t.Run("list search sorted by name", testList(model.ListOptions{Search: "stock"}, []string{"p2", "p4"}, 2))
+	t.Run("list search by score", testList(model.ListOptions{Search: "stock", SortBy: "score.desc"}, []string{"p4", "p2"}, 2))
+	t.Run("list score without search", testList(model.ListOptions{SortBy: "score.desc", Limit: 2}, []string{"p3", "p1"}, 4))
+	t.Run("list topic", testList(model.ListOptions{Topic: "t1"}, []string{"p1", "p2"}, 2))
+	t.Run("list message", testList(model.ListOptions{Message: "m"}, []string{"p3"}, 1))
+	t.Run("list lane", testList(model.ListOptions{Lane: "l"}, []string{"p4"}, 1))
+	t.Run("list timer", testList(model.ListOptions{TimerType: "timeCycle"}, []string{"p2"}, 1))
+	t.Run("list task count", testList(model.ListOptions{MinTaskCount: 2, MaxTaskCount: 4}, []string{"p2"}, 1))
+	t.Run("list public", testList(model.ListOptions{Public: true}, []string{"p2"}, 1))
+	t.Run("list category", testList(model.ListOptions{Category: "logistics"}, []string{"p2", "p4"}, 2))
+	t.Run("list public category", testList(model.ListOptions{Public: true, Category: "logistics", Search: "stock"}, []string{"p2"}, 1))
+	t.Run("list unknown category", testList(model.ListOptions{Category: "log"}, []string{}, 0))
+	t.Run("list tag", testList(model.ListOptions{Tag: "t-a"}, []string{"p1", "p2"}, 2))
+	t.Run("list folder", testList(model.ListOptions{Folder: "team"}, []string{"p1", "p2"}, 2))
+	t.Run("list sub folder", testList(model.ListOptions{Folder: "team/sub"}, []string{"p2"}, 1))
+	t.Run("list folder and tag", testList(model.ListOptions{Folder: "team", Tag: "t-b"}, []string{"p2"}, 1))
+
+	testValueCounts := func(field string, options model.ListOptions, expected []model.ValueCount) func(t *testing.T) {
+		return func(t *testing.T) {
+			actual, err := db.CountProcessValues(ctx, field, options)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("\na=%#v\ne=%#v\n", actual, expected)
+			}
+		}
+	}
+	t.Run("count categories", testValueCounts(model.ProcessCategoriesField, model.ListOptions{}, []model.ValueCount{{Value: "finance", Count: 1}, {Value: "logistics", Count: 2}}))
+	t.Run("count public categories", testValueCounts(model.ProcessCategoriesField, model.ListOptions{Public: true}, []model.ValueCount{{Value: "finance", Count: 1}, {Value: "logistics", Count: 1}}))
+	t.Run("count categories of search", testValueCounts(model.ProcessCategoriesField, model.ListOptions{Search: "x"}, []model.ValueCount{{Value: "logistics", Count: 1}}))
+	t.Run("count categories without matches", testValueCounts(model.ProcessCategoriesField, model.ListOptions{Search: ".*"}, []model.ValueCount{}))
+	t.Run("count tags", testValueCounts(model.ProcessTagsField, model.ListOptions{}, []model.ValueCount{{Value: "t-a", Count: 2}, {Value: "t-b", Count: 1}}))
+	t.Run("count tags of ids", testValueCounts(model.ProcessTagsField, model.ListOptions{Ids: []string{"p1", "p4"}}, []model.ValueCount{{Value: "t-a", Count: 1}}))
+	t.Run("count folders", testValueCounts(model.ProcessFolderField, model.ListOptions{}, []model.ValueCount{{Value: "team", Count: 1}, {Value: "team/sub", Count: 1}, {Value: "teams", Count: 1}}))
+	t.Run("count sub folders", testValueCounts(model.ProcessFolderField, model.ListOptions{Folder: "team"}, []model.ValueCount{{Value: "team", Count: 1}, {Value: "team/sub", Count: 1}}))
+
+	testPages := func(sortBy string, expectedIds []string) func(t *testing.T) {
+		return func(t *testing.T) {
+			actualIds := []string{}
+			var after *model.ListCursor
+			for range expectedIds {
+				page, total, err := db.ListProcesses(ctx, model.ListOptions{SortBy: sortBy, Limit: 2, After: after, WithoutTotal: true})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if total != 0 {
+					t.Error(total)
+				}
+				for _, process := range page {
+					actualIds = append(actualIds, process.Id)
+				}
+				if len(page) < 2 {
+					break
+				}
+				cursor, err := model.NewListCursor(sortBy, page[len(page)-1])
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				token, err := cursor.Encode()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				cursor, err = model.ParseListCursor(token)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				after = &cursor
+			}
+			if !reflect.DeepEqual(actualIds, expectedIds) {
+				t.Errorf("\na=%#v\ne=%#v\n", actualIds, expectedIds)
+			}
+		}
+	}
+	t.Run("list public fields", func(t *testing.T) {
+		actual, _, err := db.ListProcesses(ctx, model.ListOptions{Public: true, Fields: []string{"name"}})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := []model.Process{{Id: "p2", Name: "b 1"}}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", actual, expected)
+		}
+	})
+	t.Run("list fields", func(t *testing.T) {
+		actual, _, err := db.ListProcesses(ctx, model.ListOptions{Fields: []string{"name", "metadata"}, Ids: []string{"p1"}})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := []model.Process{{Id: "p1", Name: processes[0].Name, Metadata: processes[0].Metadata}}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", actual, expected)
+		}
+	})
+	t.Run("list pages by name", testPages("name.asc", []string{"p3", "p1", "p2", "p4"}))
+	t.Run("list pages by name desc", testPages("name.desc", []string{"p4", "p2", "p1", "p3"}))
+	t.Run("list pages by number", testPages("metadata.task_count.asc", []string{"p4", "p1", "p2", "p3"}))
+	t.Run("list pages with ties", testPages("revision.desc", []string{"p1", "p4", "p3", "p2"}))
+	t.Run("list pages by missing field", testPages("unknown.asc", []string{"p1", "p2", "p3", "p4"}))
+	t.Run("list cursor keeps total", func(t *testing.T) {
+		after := model.ListCursor{SortBy: "name.asc", Value: "b 1", Id: "p2"}
+		testList(model.ListOptions{After: &after, Search: "b"}, []string{"p4"}, 2)(t)
+	})
+
+	t.Run("check id list", func(t *testing.T) {
+		missingInDb, missingInInput, err := db.CheckIdList([]string{"p1", "p2", "p5"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		sort.Strings(missingInInput)
+		if !reflect.DeepEqual(missingInDb, []string{"p5"}) || !reflect.DeepEqual(missingInInput, []string{"p3"}) {
+			t.Error(missingInDb, missingInInput)
+		}
+	})
+
+	t.Run("removed processes", func(t *testing.T) {
+		removal := model.ProcessRemoval{Reason: model.RemovalReasonUserDelete, UserId: "u1", Date: 1, Until: 2}
+		ok, err := db.SetProcessIfRevision(ctx, model.Process{Id: "p5", Name: "a 3", Revision: 1, LastUpdatedUnix: old, Tags: []string{"t-a"}, Removal: &removal}, 0)
+		if err != nil || !ok {
+			t.Error(ok, err)
+			return
+		}
+		testList(model.ListOptions{}, []string{"p3", "p1", "p2", "p4"}, 4)(t)
+		testList(model.ListOptions{Removed: true}, []string{"p5"}, 1)(t)
+		testValueCounts(model.ProcessTagsField, model.ListOptions{}, []model.ValueCount{{Value: "t-a", Count: 2}, {Value: "t-b", Count: 1}})(t)
+		list, _, err := db.ListProcesses(ctx, model.ListOptions{Removed: true, Fields: []string{"_id", "removal"}})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(list) != 1 || list[0].Removal == nil || *list[0].Removal != removal {
+			t.Errorf("%#v", list)
+		}
+		_, missingInInput, err := db.CheckIdList([]string{"p1", "p2"})
+		if err != nil || slices.Contains(missingInInput, "p5") {
+			t.Error(missingInInput, err)
+		}
+		err = db.DeleteProcess(ctx, "p5")
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("revisions", func(t *testing.T) {
+		for i := int64(1); i <= 3; i++ {
+			err := db.SetProcessRevision(ctx, model.ProcessRevision{ProcessId: "p1", Revision: i, UserId: "u", Date: i, Process: model.Process{Id: "p1", Revision: i, BpmnXml: "bpmn", SvgXml: "svg"}})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		list, total, err := db.ListProcessRevisions(ctx, "p1", 2, 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if total != 3 || len(list) != 2 || list[0].Revision != 3 || list[1].Revision != 2 || list[0].Process.BpmnXml != "" || list[0].Process.SvgXml != "" {
+			t.Errorf("%v %#v", total, list)
+		}
+		revision, exists, err := db.ReadProcessRevision(ctx, "p1", 2)
+		if err != nil || !exists || revision.Process.BpmnXml != "bpmn" {
+			t.Error(revision, exists, err)
+		}
+		_, exists, err = db.ReadProcessRevision(ctx, "p1", 4)
+		if err != nil || exists {
+			t.Error(exists, err)
+		}
+	})
+
+	t.Run("delete process", func(t *testing.T) {
+		err := db.DeleteProcess(ctx, "p1")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, exists, err := db.ReadProcess(ctx, "p1")
+		if err != nil || exists {
+			t.Error(exists, err)
+		}
+		list, total, err := db.ListProcessRevisions(ctx, "p1", 0, 0)
+		if err != nil || total != 0 || len(list) != 0 {
+			t.Error(list, total, err)
+		}
+	})
+
+	t.Run("templates", func(t *testing.T) {
+		for _, template := range []model.ProcessTemplate{
+			{Id: "t1", Name: "b", Owner: "u1"},
+			{Id: "t2", Name: "a", Owner: "u2", Publish: true, Description: "shared"},
+			{Id: "t3", Name: "c", Owner: "u2"},
+		} {
+			err := db.SetTemplate(ctx, template)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		testTemplates := func(options model.TemplateListOptions, expectedIds []string, expectedTotal int64) {
+			actual, total, err := db.ListTemplates(ctx, options)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			actualIds := []string{}
+			for _, template := range actual {
+				actualIds = append(actualIds, template.Id)
+			}
+			if !reflect.DeepEqual(actualIds, expectedIds) || total != expectedTotal {
+				t.Errorf("\na=%#v %v\ne=%#v %v\n", actualIds, total, expectedIds, expectedTotal)
+			}
+		}
+		testTemplates(model.TemplateListOptions{}, []string{"t2", "t1", "t3"}, 3)
+		testTemplates(model.TemplateListOptions{Owner: "u1"}, []string{"t1"}, 1)
+		testTemplates(model.TemplateListOptions{Owner: "u1", IncludePublished: true}, []string{"t2", "t1"}, 2)
+		testTemplates(model.TemplateListOptions{Owner: "u1", IncludePublished: true, Search: "SHARED"}, []string{"t2"}, 1)
+		testTemplates(model.TemplateListOptions{SortBy: "name.desc", Limit: 1, Offset: 1}, []string{"t1"}, 3)
+		err := db.DeleteTemplate(ctx, "t1")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, exists, err := db.ReadTemplate(ctx, "t1")
+		if err != nil || exists {
+			t.Error(exists, err)
+		}
+	})
+
+	t.Run("audit", func(t *testing.T) {
+		before := model.ProcessPermissions{UserPermissions: map[string]model.PermissionsMap{"u1": {Read: true, Administrate: true}}, GroupPermissions: map[string]model.PermissionsMap{}}
+		for _, record := range []model.AuditRecord{
+			{Id: "a2", ProcessId: "p2", Action: model.AuditOwnerChange, UserId: "u1", Date: 2, PermissionsBefore: &before, PermissionsAfter: &before, OwnerBefore: "u1", OwnerAfter: "u2"},
+			{Id: "a1", ProcessId: "p2", Action: model.AuditPermissionsChange, UserId: "u1", Date: 1},
+			{Id: "a3", ProcessId: "p3", Action: model.AuditPermissionsChange, UserId: "u2", Date: 3},
+		} {
+			err := db.SetAuditRecord(ctx, record)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		list, total, err := db.ListAuditRecords(ctx, "p2", 1, 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := []model.AuditRecord{{Id: "a2", ProcessId: "p2", Action: model.AuditOwnerChange, UserId: "u1", Date: 2, PermissionsBefore: &before, PermissionsAfter: &before, OwnerBefore: "u1", OwnerAfter: "u2"}}
+		if total != 2 || !reflect.DeepEqual(list, expected) {
+			t.Errorf("\na=%#v %v\ne=%#v\n", list, total, expected)
+		}
+		list, total, err = db.ListAuditRecords(ctx, "p2", 0, 1)
+		if err != nil || total != 2 || len(list) != 1 || list[0].Id != "a1" {
+			t.Error(list, total, err)
+		}
+	})
+
+	t.Run("outbox", func(t *testing.T) {
+		for _, entry := range []model.OutboxEntry{
+			{Id: "o3", ProcessId: "p2", Command: model.ProcessChangeDelete, Created: 3, NextAttempt: 30},
+			{Id: "o1", ProcessId: "p2", Command: model.ProcessChangePut, Created: 1, NextAttempt: 10, Process: &model.Process{Id: "p2"}},
+			{Id: "o2", ProcessId: "p3", Command: model.ProcessChangePut, Created: 2, NextAttempt: 20, Process: &model.Process{Id: "p3"}},
+			{Id: "o4", ProcessId: "p3", Command: model.ProcessChangeDelete, Created: 4, Parked: true},
+		} {
+			err := db.SetOutboxEntry(ctx, entry)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		testOutbox := func(processId string, due int64, limit int64, expectedIds []string) {
+			actual, err := db.ListOutboxEntries(ctx, processId, due, limit)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			actualIds := []string{}
+			for _, entry := range actual {
+				actualIds = append(actualIds, entry.Id)
+			}
+			if !reflect.DeepEqual(actualIds, expectedIds) {
+				t.Errorf("\na=%#v\ne=%#v\n", actualIds, expectedIds)
+			}
+		}
+		testClaim := func(id string, now int64, until int64, expected bool) {
+			claimed, err := db.ClaimOutboxEntry(ctx, id, now, until)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if claimed != expected {
+				t.Errorf("claim %v at %v: a=%#v e=%#v", id, now, claimed, expected)
+			}
+		}
+		testOutbox("", 0, 0, []string{"o1", "o2", "o3"})
+		testOutbox("", 0, 2, []string{"o1", "o2"})
+		testOutbox("p2", 0, 0, []string{"o1", "o3"})
+		testOutbox("", 20, 0, []string{"o1", "o2"})
+
+		testClaim("o2", 20, 25, true)
+		testClaim("o2", 21, 26, false)
+		testClaim("o4", 20, 25, false)
+		testOutbox("", 21, 0, []string{"o1"})
+		testOutbox("p3", 0, 0, []string{"o2"})
+		testClaim("o2", 25, 30, true)
+		testOutbox("", 29, 0, []string{"o1"})
+		testOutbox("", 30, 0, []string{"o1", "o2", "o3"})
+
+		err := db.DeleteOutboxEntry(ctx, "o1")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		testOutbox("p2", 0, 1, []string{"o3"})
+
+		for processId, expected := range map[string]int64{"p2": 1, "p3": 2, "p4": 0} {
+			count, err := db.CountOutboxEntries(ctx, processId)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if count != expected {
+				t.Errorf("count %v: a=%v e=%v", processId, count, expected)
+			}
+		}
+	})
+}
@@ -61,6 +61,48 @@ func MongoTestServer(ctx context.Context, wg *sync.WaitGroup) (hostport string,
 	return hostport, containerip, err
 }
 
+func PostgresTestServer(ctx context.Context, wg *sync.WaitGroup) (hostport string, containerip string, err error) {
+	log.Println("start postgres")
+	c, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "postgres:16",
+			ExposedPorts: []string{"5432/tcp"},
+			Env: map[string]string{
+				"POSTGRES_PASSWORD": "postgres",
+				"POSTGRES_DB":       "process_repository",
+			},
+			WaitingFor: wait.ForAll(
+				wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
+				wait.ForListeningPort("5432/tcp"),
+			),
+			Tmpfs: map[string]string{"/var/lib/postgresql/data": "rw"},
+		},
+		Started: true,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		log.Println("DEBUG: remove container postgres", c.Terminate(context.Background()))
+	}()
+
+	containerip, err = c.ContainerIP(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	temp, err := c.MappedPort(ctx, "5432/tcp")
+	if err != nil {
+		return "", "", err
+	}
+	hostport = temp.Port()
+
+	return hostport, containerip, err
+}
+
 var Kafka = docker.Kafka
 
 func PermissionsV2(ctx context.Context, wg *sync.WaitGroup, mongoUrl string, kafkaUrl string) (hostPort string, ipAddress string, err error) {
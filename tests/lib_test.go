@@ -100,7 +100,7 @@ func Test(t *testing.T) {
 	time.Sleep(5 * time.Second)
 
 	var p4c model.Process
-	err = PostJSON(userjwt, "http://localhost:"+conf.ServerPort+"/processes/"+p4.Id+"/publish", model.PublicCommand{Publish: true, Description: "publish_description4"}, &p4c)
+	err = PostJSON(userjwt, "http://localhost:"+conf.ServerPort+"/processes/"+p4.Id+"/publish", model.PublicCommand{Publish: true, Description: "publish_description4", Categories: []string{"b", " a", "a"}}, &p4c)
 	if err != nil {
 		t.Error(err)
 		return
@@ -127,7 +127,7 @@ func Test(t *testing.T) {
 	}
 
 	list := []model.Process{}
-	err = GetJSON(userjwt, "http://localhost:"+conf.ServerPort+"/processes", &list)
+	err = GetJSON(userjwt, "http://localhost:"+conf.ServerPort+"/processes?fields=full", &list)
 	if err != nil {
 		t.Error(err)
 		return
@@ -137,6 +137,40 @@ func Test(t *testing.T) {
 		t.Fatal(list, "\n", p4c)
 	}
 
+	summaryList := []model.Process{}
+	err = GetJSON(userjwt, "http://localhost:"+conf.ServerPort+"/processes", &summaryList)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(summaryList) != 1 || summaryList[0].Id != p4c.Id || summaryList[0].BpmnXml != "" || summaryList[0].SvgXml != "" {
+		t.Fatal(summaryList)
+	}
+
+	if !reflect.DeepEqual(p4c.Categories, []string{"a", "b"}) {
+		t.Fatal(p4c.Categories)
+	}
+
+	categoryList := []map[string]interface{}{}
+	err = GetJSON(userjwt, "http://localhost:"+conf.ServerPort+"/processes?category=b&fields=name&limit=10", &categoryList)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !reflect.DeepEqual(categoryList, []map[string]interface{}{{"_id": p4c.Id, "name": p4c.Name}}) {
+		t.Fatal(categoryList)
+	}
+
+	categories := []model.ValueCount{}
+	err = GetJSON(userjwt, "http://localhost:"+conf.ServerPort+"/v2/processes/categories", &categories)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !reflect.DeepEqual(categories, []model.ValueCount{{Value: "a", Count: 1}, {Value: "b", Count: 1}}) {
+		t.Fatal(categories)
+	}
+
 	isStr := p4c.BpmnXml
 	wantStr := createTestXmlString("p4")
 
@@ -213,6 +247,19 @@ func GetJSON(token string, url string, result interface{}) (err error) {
 	return json.NewDecoder(resp.Body).Decode(result)
 }
 
+// processSummaries returns the processes like the default summary representation of process lists
+func processSummaries(processes []model.Process) (result []model.Process) {
+	for _, process := range processes {
+		process.BpmnXml = ""
+		process.SvgXml = ""
+		result = append(result, process)
+	}
+	if processes != nil && result == nil {
+		result = []model.Process{}
+	}
+	return result
+}
+
 func createTestXmlString(processId string) (result string) {
 	return `<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:bpmndi="http://www.omg.org/spec/BPMN/20100524/DI" xmlns:dc="http://www.omg.org/spec/DD/20100524/DC" xmlns:di="http://www.omg.org/spec/DD/20100524/DI" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" id="Definitions_1" targetNamespace="http://bpmn.io/schema/bpmn">
   <bpmndi:BPMNDiagram id="BPMNDiagram_1">
@@ -177,6 +177,7 @@ func TestList(t *testing.T) {
 				t.Error(err)
 				return
 			}
+			expected := processSummaries(expected)
 			if !reflect.DeepEqual(actual, expected) {
 				t.Errorf("\n%#v\n%#v\n", actual, expected)
 				return
@@ -237,4 +238,154 @@ func TestList(t *testing.T) {
 		testList(userjwt1, "/v2/processes?search="+url.QueryEscape("a 1"), []model.Process{p1})
 	})
 
+	t.Run("task count", func(t *testing.T) {
+		testList(userjwt1, "/v2/processes?min_task_count=1&max_task_count=1", []model.Process{p1, p3, p2, p4})
+	})
+
+	t.Run("task count and search", func(t *testing.T) {
+		testList(userjwt1, "/v2/processes?min_task_count=1&search=b", []model.Process{p2, p4})
+	})
+
+	t.Run("continuation token", func(t *testing.T) {
+		actualIds := []string{}
+		path := "/v2/processes?limit=3&total=false"
+		for path != "" {
+			resp, err := Get(userjwt1, "http://localhost:"+conf.ServerPort+path)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			page := []model.Process{}
+			err = json.NewDecoder(resp.Body).Decode(&page)
+			resp.Body.Close()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if resp.Header.Get("X-Total-Count") != "" {
+				t.Error(resp.Header.Get("X-Total-Count"))
+			}
+			for _, process := range page {
+				actualIds = append(actualIds, process.Id)
+			}
+			path = ""
+			if token := resp.Header.Get("X-Continuation-Token"); token != "" {
+				path = "/v2/processes?limit=3&total=false&continuation_token=" + url.QueryEscape(token)
+			}
+		}
+		expectedIds := []string{p1.Id, p3.Id, p2.Id, p4.Id}
+		if !reflect.DeepEqual(actualIds, expectedIds) {
+			t.Errorf("\na=%#v\ne=%#v\n", actualIds, expectedIds)
+		}
+	})
+
+	t.Run("continuation token with other sort", func(t *testing.T) {
+		token, _ := model.ListCursor{SortBy: "name.asc", Value: "a 1", Id: p1.Id}.Encode()
+		_, err := Get(userjwt1, "http://localhost:"+conf.ServerPort+"/v2/processes?sort=name.desc&continuation_token="+url.QueryEscape(token))
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		actual := []map[string]interface{}{}
+		err := GetJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/v2/processes?limit=1&fields=name", &actual)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := []map[string]interface{}{{"_id": p1.Id, "name": p1.Name}}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", actual, expected)
+		}
+	})
+
+	t.Run("full fields", func(t *testing.T) {
+		actual := []model.Process{}
+		err := GetJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/v2/processes?limit=1&fields=full", &actual)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !reflect.DeepEqual(actual, []model.Process{p1}) {
+			t.Errorf("\na=%#v\ne=%#v\n", actual, []model.Process{p1})
+		}
+	})
+
+	t.Run("unknown fields", func(t *testing.T) {
+		_, err := Get(userjwt1, "http://localhost:"+conf.ServerPort+"/v2/processes?fields=unknown")
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("search highlights", func(t *testing.T) {
+		actual := []model.Process{}
+		err := GetJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/v2/processes?sort=score.desc&search="+url.QueryEscape("x test"), &actual)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(actual) != 2 || actual[0].Id != p1.Id || actual[1].Id != p4.Id {
+			t.Errorf("%#v", actual)
+			return
+		}
+		expected := []model.SearchHighlight{
+			{Field: "description", Snippet: "<em>x</em>"},
+			{Field: "texts", Snippet: "<em>Test</em>"},
+		}
+		if !reflect.DeepEqual(actual[0].Highlights, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", actual[0].Highlights, expected)
+		}
+	})
+
+	t.Run("bulk organize", func(t *testing.T) {
+		moved := []model.BulkResult{}
+		err := PostJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/v2/processes/bulk/move", model.BulkMoveCommand{Ids: []string{p1.Id, p3.Id}, Folder: "/team/a/"}, &moved)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expectedMoved := []model.BulkResult{{ProcessId: p1.Id, Revision: p1.Revision + 1}, {ProcessId: p3.Id, Revision: p3.Revision + 1}}
+		if !reflect.DeepEqual(moved, expectedMoved) {
+			t.Errorf("\na=%#v\ne=%#v\n", moved, expectedMoved)
+		}
+		tagged := []model.BulkResult{}
+		err = PostJSON(userjwt2, "http://localhost:"+conf.ServerPort+"/v2/processes/bulk/tags", model.BulkTagCommand{Ids: []string{p1.Id, p2.Id}, Add: []string{"x"}}, &tagged)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(tagged) != 2 || tagged[0].Error == "" || tagged[1].Error != "" || tagged[1].Revision != p2.Revision+1 {
+			t.Errorf("%#v", tagged)
+		}
+		folders := []model.ValueCount{}
+		err = GetJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/v2/processes/folders", &folders)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !reflect.DeepEqual(folders, []model.ValueCount{{Value: "team/a", Count: 2}}) {
+			t.Errorf("%#v", folders)
+		}
+		tags := []model.ValueCount{}
+		err = GetJSON(userjwt2, "http://localhost:"+conf.ServerPort+"/v2/processes/tags", &tags)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !reflect.DeepEqual(tags, []model.ValueCount{{Value: "x", Count: 1}}) {
+			t.Errorf("%#v", tags)
+		}
+		list := []map[string]interface{}{}
+		err = GetJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/v2/processes?fields=name&folder=team", &list)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expectedList := []map[string]interface{}{{"_id": p1.Id, "name": p1.Name}, {"_id": p3.Id, "name": p3.Name}}
+		if !reflect.DeepEqual(list, expectedList) {
+			t.Errorf("\na=%#v\ne=%#v\n", list, expectedList)
+		}
+	})
 }
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+)
+
+func TestExtractProcessMetadata(t *testing.T) {
+	t.Run("test xml", func(t *testing.T) {
+		metadata := model.ExtractProcessMetadata(createTestXmlString("p"))
+		if metadata.TaskCount != 1 || metadata.Version != model.ProcessMetadataVersion || len(metadata.Topics) != 0 {
+			t.Errorf("%#v", metadata)
+		}
+	})
+
+	t.Run("invalid xml", func(t *testing.T) {
+		metadata := model.ExtractProcessMetadata("<bpmn:definitions")
+		if metadata.TaskCount != 0 || metadata.Topics == nil {
+			t.Errorf("%#v", metadata)
+		}
+	})
+
+	t.Run("derived fields", func(t *testing.T) {
+		bpmn := `<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" id="Definitions_1" targetNamespace="http://bpmn.io/schema/bpmn">
+  <bpmn:process id="p" isExecutable="true">
+    <bpmn:laneSet id="LaneSet_1">
+      <bpmn:lane id="Lane_1" name="devices"/>
+      <bpmn:lane id="Lane_2" name="analytics"/>
+    </bpmn:laneSet>
+    <bpmn:startEvent id="start">
+      <bpmn:timerEventDefinition id="TimerEventDefinition_1"><bpmn:timeCycle>R/PT1H</bpmn:timeCycle></bpmn:timerEventDefinition>
+    </bpmn:startEvent>
+    <bpmn:serviceTask id="task1" name="check stock" camunda:type="external" camunda:topic="pessimistic">
+      <bpmn:documentation>Reserves the &lt;items&gt; of an order</bpmn:documentation>
+      <bpmn:extensionElements>
+        <camunda:inputOutput>
+          <camunda:inputParameter name="payload">{}</camunda:inputParameter>
+          <camunda:outputParameter name="result">${result}</camunda:outputParameter>
+        </camunda:inputOutput>
+      </bpmn:extensionElements>
+    </bpmn:serviceTask>
+    <bpmn:serviceTask id="task2" camunda:type="external" camunda:topic="optimistic"/>
+    <bpmn:serviceTask id="task3" camunda:type="external" camunda:topic="pessimistic"/>
+    <bpmn:intermediateCatchEvent id="wait">
+      <bpmn:timerEventDefinition><bpmn:timeDuration> PT5M </bpmn:timeDuration></bpmn:timerEventDefinition>
+    </bpmn:intermediateCatchEvent>
+    <bpmn:endEvent id="end"><bpmn:signalEventDefinition signalRef="Signal_1"/></bpmn:endEvent>
+  </bpmn:process>
+  <bpmn:message id="Message_1" name="order"/>
+  <bpmn:signal id="Signal_1" name="done"/>
+</bpmn:definitions>`
+		expected := model.ProcessMetadata{
+			Version:   model.ProcessMetadataVersion,
+			TaskCount: 3,
+			Lanes:     []string{"analytics", "devices"},
+			Messages:  []string{"order"},
+			Signals:   []string{"done"},
+			Timers: []model.TimerDefinition{
+				{ElementId: "start", Type: "timeCycle", Value: "R/PT1H"},
+				{ElementId: "wait", Type: "timeDuration", Value: "PT5M"},
+			},
+			Topics:           []string{"optimistic", "pessimistic"},
+			InputParameters:  []string{"payload"},
+			OutputParameters: []string{"result"},
+			Texts:            []string{"Reserves the <items> of an order", "analytics", "check stock", "devices", "done", "order"},
+		}
+		metadata := model.ExtractProcessMetadata(bpmn)
+		if !reflect.DeepEqual(metadata, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", metadata, expected)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+)
+
+func TestOrganization(t *testing.T) {
+	t.Run("normalize tags", func(t *testing.T) {
+		actual, err := model.NormalizeTags([]string{"b ", "a", " b"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := []string{"a", "b"}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", actual, expected)
+		}
+		_, err = model.NormalizeTags([]string{""})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("normalize folder", func(t *testing.T) {
+		for folder, expected := range map[string]string{
+			"":                  "",
+			"/":                 "",
+			"team":              "team",
+			" /team // sub / ":  "team/sub",
+			"team/sub folder/x": "team/sub folder/x",
+		} {
+			actual, err := model.NormalizeFolder(folder)
+			if err != nil || actual != expected {
+				t.Errorf("%#v: \na=%#v\ne=%#v\n%v", folder, actual, expected, err)
+			}
+		}
+		for _, folder := range []string{strings.Repeat("f", model.MaxFolderLength+1), "a\nb"} {
+			_, err := model.NormalizeFolder(folder)
+			if err == nil {
+				t.Errorf("expected error for %#v", folder)
+			}
+		}
+	})
+
+	t.Run("in folder", func(t *testing.T) {
+		for _, c := range []struct {
+			folder   string
+			parent   string
+			expected bool
+		}{
+			{"team", "", true},
+			{"", "", true},
+			{"team", "team", true},
+			{"team/sub", "team", true},
+			{"teams", "team", false},
+			{"", "team", false},
+			{"team", "team/sub", false},
+		} {
+			if actual := model.InFolder(c.folder, c.parent); actual != c.expected {
+				t.Errorf("%#v", c)
+			}
+		}
+	})
+}
@@ -0,0 +1,387 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package tests
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/process-model-repository/lib"
+	"github.com/SENERGY-Platform/process-model-repository/lib/auth"
+	"github.com/SENERGY-Platform/process-model-repository/lib/config"
+	"github.com/SENERGY-Platform/process-model-repository/lib/contextwg"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+)
+
+func TestProcessPermissionsModel(t *testing.T) {
+	all := model.PermissionsMap{Read: true, Write: true, Execute: true, Administrate: true}
+	read := model.PermissionsMap{Read: true}
+
+	t.Run("normalized", func(t *testing.T) {
+		actual := model.ProcessPermissions{
+			UserPermissions: map[string]model.PermissionsMap{"u1": all, "u2": {}},
+			RolePermissions: map[string]model.PermissionsMap{"user": read, "guest": {}},
+		}.Normalized()
+		expected := model.ProcessPermissions{
+			UserPermissions:  map[string]model.PermissionsMap{"u1": all},
+			GroupPermissions: map[string]model.PermissionsMap{},
+			RolePermissions:  map[string]model.PermissionsMap{"user": read},
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", actual, expected)
+		}
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		err := model.ProcessPermissions{UserPermissions: map[string]model.PermissionsMap{"u1": all, "u2": read}}.Validate()
+		if err != nil {
+			t.Error(err)
+		}
+		err = model.ProcessPermissions{
+			UserPermissions:  map[string]model.PermissionsMap{"u2": read},
+			GroupPermissions: map[string]model.PermissionsMap{"g": all},
+		}.Validate()
+		if err != nil {
+			t.Error(err)
+		}
+		err = model.ProcessPermissions{
+			UserPermissions:  map[string]model.PermissionsMap{"u2": read},
+			GroupPermissions: map[string]model.PermissionsMap{"g": read},
+			RolePermissions:  map[string]model.PermissionsMap{"user": read},
+		}.Validate()
+		if err != model.ErrMissingAdministrator {
+			t.Error(err)
+		}
+	})
+}
+
+func TestProcessPermissionsApi(t *testing.T) {
+	conf, err := config.Load("../config.json")
+	if err != nil {
+		log.Fatal("ERROR: unable to load config", err)
+	}
+	conf.Debug = true
+	conf.ConnectivityTest = false
+
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctx = contextwg.WithWaitGroup(ctx, wg)
+
+	_, mongoIp, err := MongoTestServer(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	conf.MongoUrl = "mongodb://" + mongoIp + ":27017"
+
+	conf.KafkaUrl, err = Kafka(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	time.Sleep(2 * time.Second)
+
+	_, permIp, err := PermissionsV2(ctx, wg, conf.MongoUrl, conf.KafkaUrl)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	conf.PermissionsV2Url = "http://" + permIp + ":8080"
+
+	port, err := getFreePort()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	conf.ServerPort = strconv.Itoa(port)
+
+	err = lib.Start(ctx, conf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	time.Sleep(2 * time.Second)
+
+	var p model.Process
+	err = PostJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes", model.Process{
+		Name:    "shared",
+		BpmnXml: createTestXmlString("shared"),
+	}, &p)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	time.Sleep(2 * time.Second)
+
+	all := model.PermissionsMap{Read: true, Write: true, Execute: true, Administrate: true}
+	owner := model.ProcessPermissions{
+		UserPermissions:  map[string]model.PermissionsMap{userid1: all},
+		GroupPermissions: map[string]model.PermissionsMap{},
+		RolePermissions:  map[string]model.PermissionsMap{},
+	}
+	shared := model.ProcessPermissions{
+		UserPermissions:  map[string]model.PermissionsMap{userid1: all, userid2: {Read: true, Execute: true}},
+		GroupPermissions: map[string]model.PermissionsMap{},
+		RolePermissions:  map[string]model.PermissionsMap{},
+	}
+
+	t.Run("read initial", func(t *testing.T) {
+		actual := model.ProcessPermissions{}
+		err := GetJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/permissions", &actual)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !reflect.DeepEqual(actual, owner) {
+			t.Errorf("\na=%#v\ne=%#v\n", actual, owner)
+		}
+	})
+
+	t.Run("read without administrate right", func(t *testing.T) {
+		_, err := Get(userjwt2, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/permissions")
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("grant", func(t *testing.T) {
+		actual := model.ProcessPermissions{}
+		err := PutJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/permissions", shared, &actual)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !reflect.DeepEqual(actual, shared) {
+			t.Errorf("\na=%#v\ne=%#v\n", actual, shared)
+		}
+		read := model.Process{}
+		err = GetJSON(userjwt2, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"?p=x", &read)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("refuse removal of last administrator", func(t *testing.T) {
+		err := PutJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/permissions", model.ProcessPermissions{
+			UserPermissions: map[string]model.PermissionsMap{userid1: {Read: true}, userid2: {Read: true}},
+		}, nil)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		err := PutJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/permissions", owner, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err = Get(userjwt2, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("audit", func(t *testing.T) {
+		records := []model.AuditRecord{}
+		err := GetJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/audit", &records)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(records) != 2 {
+			t.Errorf("%#v", records)
+			return
+		}
+		if records[0].Action != model.AuditPermissionsChange || records[0].UserId != userid1 || !reflect.DeepEqual(*records[0].PermissionsBefore, shared) || !reflect.DeepEqual(*records[0].PermissionsAfter, owner) {
+			t.Errorf("%#v", records[0])
+		}
+		if !reflect.DeepEqual(*records[1].PermissionsBefore, owner) || !reflect.DeepEqual(*records[1].PermissionsAfter, shared) {
+			t.Errorf("%#v", records[1])
+		}
+	})
+
+	t.Run("share with group", func(t *testing.T) {
+		ownerInTeam, err := auth.CreateTokenWithGroups("test", userid1, []string{"user"}, []string{"team"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		member, err := auth.CreateTokenWithGroups("test", "3", []string{"user"}, []string{"team"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		outsider, err := auth.CreateTokenWithGroups("test", "4", []string{"user"}, []string{"other"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		teamShared := model.ProcessPermissions{
+			UserPermissions:  map[string]model.PermissionsMap{userid1: all},
+			GroupPermissions: map[string]model.PermissionsMap{"team": {Read: true, Execute: true}},
+			RolePermissions:  map[string]model.PermissionsMap{},
+		}
+		err = PutJSON(ownerInTeam.Jwt(), "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/permissions", teamShared, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = PutJSON(ownerInTeam.Jwt(), "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/permissions", model.ProcessPermissions{
+			UserPermissions:  map[string]model.PermissionsMap{userid1: all},
+			GroupPermissions: map[string]model.PermissionsMap{"team": {Read: true, Execute: true}, "other": {Read: true}},
+			RolePermissions:  map[string]model.PermissionsMap{},
+		}, nil)
+		if err == nil {
+			t.Error("expected error for a group without membership")
+		}
+		for token, expected := range map[string][]string{member.Jwt(): {p.Id}, outsider.Jwt(): {}} {
+			list := []model.Process{}
+			err = GetJSON(token, "http://localhost:"+conf.ServerPort+"/v2/processes?p=x", &list)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			actual := []string{}
+			for _, process := range list {
+				actual = append(actual, process.Id)
+			}
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("\na=%#v\ne=%#v\n", actual, expected)
+			}
+		}
+	})
+
+	t.Run("group administrator", func(t *testing.T) {
+		ownerInTeam, err := auth.CreateTokenWithGroups("test", userid1, []string{"user"}, []string{"team"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		groupAdministrated := model.ProcessPermissions{
+			UserPermissions:  map[string]model.PermissionsMap{userid1: {Read: true}},
+			GroupPermissions: map[string]model.PermissionsMap{"team": all},
+			RolePermissions:  map[string]model.PermissionsMap{},
+		}
+		err = PutJSON(ownerInTeam.Jwt(), "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/permissions", groupAdministrated, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		actual := model.ProcessPermissions{}
+		err = GetJSON(ownerInTeam.Jwt(), "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/permissions", &actual)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !reflect.DeepEqual(actual, groupAdministrated) {
+			t.Errorf("\na=%#v\ne=%#v\n", actual, groupAdministrated)
+		}
+		err = PutJSON(ownerInTeam.Jwt(), "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/permissions", owner, nil)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("role permissions require admin", func(t *testing.T) {
+		err := PutJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/permissions", model.ProcessPermissions{
+			UserPermissions: map[string]model.PermissionsMap{userid1: all},
+			RolePermissions: map[string]model.PermissionsMap{"user": {Read: true}},
+		}, nil)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("keep role permissions if omitted", func(t *testing.T) {
+		roles := map[string]model.PermissionsMap{"user": {Read: true}}
+		err := PutJSON(client.InternalAdminToken, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/permissions", model.ProcessPermissions{
+			UserPermissions: map[string]model.PermissionsMap{userid1: all},
+			RolePermissions: roles,
+		}, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		actual := model.ProcessPermissions{}
+		err = PutJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/permissions", model.ProcessPermissions{
+			UserPermissions: map[string]model.PermissionsMap{userid1: all},
+		}, &actual)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !reflect.DeepEqual(actual.RolePermissions, roles) {
+			t.Errorf("\na=%#v\ne=%#v\n", actual.RolePermissions, roles)
+		}
+	})
+
+	t.Run("transfer owner", func(t *testing.T) {
+		command := model.OwnerTransferCommand{NewOwner: userid2, PreviousOwnerRights: &model.PermissionsMap{Read: true}}
+		err := PostJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/owner", command, nil)
+		if err == nil {
+			t.Error("expected error for non admin")
+		}
+		current := model.Process{}
+		err = GetJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id, &current)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		transferred := model.Process{}
+		err = PostJSON(client.InternalAdminToken, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/owner", command, &transferred)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if transferred.Owner != userid2 || transferred.Revision != current.Revision+1 {
+			t.Errorf("%#v", transferred)
+		}
+		permissions := model.ProcessPermissions{}
+		err = GetJSON(userjwt2, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/permissions", &permissions)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := map[string]model.PermissionsMap{userid1: {Read: true}, userid2: all}
+		if !reflect.DeepEqual(permissions.UserPermissions, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", permissions.UserPermissions, expected)
+		}
+		records := []model.AuditRecord{}
+		err = GetJSON(userjwt2, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/audit?limit=1", &records)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(records) != 1 || records[0].Action != model.AuditOwnerChange || records[0].OwnerBefore != userid1 || records[0].OwnerAfter != userid2 {
+			t.Errorf("%#v", records)
+		}
+	})
+}
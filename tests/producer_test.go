@@ -0,0 +1,192 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-model-repository/lib"
+	"github.com/SENERGY-Platform/process-model-repository/lib/config"
+	"github.com/SENERGY-Platform/process-model-repository/lib/contextwg"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+	"github.com/segmentio/kafka-go"
+)
+
+func TestProcessChangeEvents(t *testing.T) {
+	conf, err := config.Load("../config.json")
+	if err != nil {
+		log.Fatal("ERROR: unable to load config", err)
+	}
+	conf.Debug = true
+	conf.ConnectivityTest = false
+	conf.TrashRetentionDays = 30
+
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctx = contextwg.WithWaitGroup(ctx, wg)
+
+	_, mongoIp, err := MongoTestServer(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	conf.MongoUrl = "mongodb://" + mongoIp + ":27017"
+
+	conf.KafkaUrl, err = Kafka(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	time.Sleep(2 * time.Second)
+
+	_, permIp, err := PermissionsV2(ctx, wg, conf.MongoUrl, conf.KafkaUrl)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	conf.PermissionsV2Url = "http://" + permIp + ":8080"
+
+	port, err := getFreePort()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	conf.ServerPort = strconv.Itoa(port)
+
+	db, _, err := lib.StartGetInternals(ctx, conf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	time.Sleep(2 * time.Second)
+
+	var p model.Process
+	t.Run("create", func(t *testing.T) {
+		err = PostJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes", model.Process{BpmnXml: createTestXmlString("events")}, &p)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	deleteProcess := func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodDelete, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		req.Header.Set("Authorization", userjwt1)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Error(resp.StatusCode)
+		}
+	}
+
+	t.Run("move into trash", deleteProcess)
+
+	t.Run("restore", func(t *testing.T) {
+		err = PostJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/restore", nil, nil)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("move into trash again", deleteProcess)
+	t.Run("delete from trash", deleteProcess)
+
+	t.Run("outbox dispatched", func(t *testing.T) {
+		entries, err := db.ListOutboxEntries(ctx, "", 0, 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(entries) != 0 {
+			t.Errorf("%#v", entries)
+		}
+	})
+
+	t.Run("check events", func(t *testing.T) {
+		reader := kafka.NewReader(kafka.ReaderConfig{
+			Brokers:     []string{conf.KafkaUrl},
+			Topic:       conf.ProcessChangeTopic,
+			StartOffset: kafka.FirstOffset,
+			MaxWait:     time.Second,
+			Logger:      log.New(io.Discard, "", 0),
+		})
+		defer reader.Close()
+		readCtx, readCancel := context.WithTimeout(ctx, 30*time.Second)
+		defer readCancel()
+		events := []model.ProcessChangeEvent{}
+		for len(events) < 5 {
+			msg, err := reader.ReadMessage(readCtx)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if string(msg.Key) != p.Id {
+				t.Error(string(msg.Key))
+			}
+			event := model.ProcessChangeEvent{}
+			err = json.Unmarshal(msg.Value, &event)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			events = append(events, event)
+		}
+		put := events[0]
+		if put.Version != model.ProcessChangeEventVersion || put.Command != model.ProcessChangePut || put.Id != p.Id || put.UserId != userid1 || put.Revision != 1 || put.Process == nil || put.Process.Name != p.Name || put.Process.Metadata.TaskCount != p.Metadata.TaskCount || put.Time != p.LastUpdatedUnix {
+			t.Errorf("%#v", put)
+		}
+		commands := []model.ProcessChangeCommand{}
+		for _, event := range events {
+			commands = append(commands, event.Command)
+		}
+		expected := []model.ProcessChangeCommand{model.ProcessChangePut, model.ProcessChangeDelete, model.ProcessChangePut, model.ProcessChangeDelete, model.ProcessChangeDelete}
+		if !reflect.DeepEqual(commands, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", commands, expected)
+			return
+		}
+		if restored := events[2]; restored.Revision != 3 || restored.Process == nil {
+			t.Errorf("%#v", restored)
+		}
+		del := events[4]
+		if del.Id != p.Id || del.UserId != userid1 || del.Process != nil {
+			t.Errorf("%#v", del)
+		}
+	})
+}
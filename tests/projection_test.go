@@ -0,0 +1,68 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+)
+
+func TestProcessProjection(t *testing.T) {
+	t.Run("parse", func(t *testing.T) {
+		fields, err := model.ParseProcessFields("name, revision,name")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := []string{"_id", "name", "revision"}
+		if !reflect.DeepEqual(fields, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", fields, expected)
+		}
+	})
+
+	t.Run("parse keywords", func(t *testing.T) {
+		fields, err := model.ParseProcessFields(model.ProcessFieldsFull)
+		if err != nil || fields != nil {
+			t.Error(fields, err)
+		}
+		fields, err = model.ParseProcessFields(model.ProcessFieldsSummary)
+		if err != nil || !reflect.DeepEqual(fields, model.ProcessSummaryFields) {
+			t.Error(fields, err)
+		}
+	})
+
+	t.Run("parse unknown", func(t *testing.T) {
+		_, err := model.ParseProcessFields("name,bpmn")
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("project", func(t *testing.T) {
+		projection, err := model.ProjectProcess(model.Process{Id: "p", Name: "n", BpmnXml: "bpmn", Revision: 2}, []string{"_id", "name", "revision"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := map[string]interface{}{"_id": "p", "name": "n", "revision": float64(2)}
+		if !reflect.DeepEqual(projection, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", projection, expected)
+		}
+	})
+}
@@ -0,0 +1,283 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-model-repository/lib"
+	"github.com/SENERGY-Platform/process-model-repository/lib/config"
+	"github.com/SENERGY-Platform/process-model-repository/lib/contextwg"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+)
+
+func TestRevisions(t *testing.T) {
+	conf, err := config.Load("../config.json")
+	if err != nil {
+		log.Fatal("ERROR: unable to load config", err)
+	}
+	conf.Debug = true
+	conf.ConnectivityTest = false
+
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctx = contextwg.WithWaitGroup(ctx, wg)
+
+	_, mongoIp, err := MongoTestServer(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	conf.MongoUrl = "mongodb://" + mongoIp + ":27017"
+
+	conf.KafkaUrl, err = Kafka(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	time.Sleep(2 * time.Second)
+
+	_, permIp, err := PermissionsV2(ctx, wg, conf.MongoUrl, conf.KafkaUrl)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	conf.PermissionsV2Url = "http://" + permIp + ":8080"
+
+	port, err := getFreePort()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	conf.ServerPort = strconv.Itoa(port)
+
+	db, _, err := lib.StartGetInternals(ctx, conf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	time.Sleep(2 * time.Second)
+
+	var p model.Process
+	t.Run("create", func(t *testing.T) {
+		err = PostJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes", model.Process{
+			Name:    "r1",
+			BpmnXml: createTestXmlString("r1"),
+			SvgXml:  "svg1",
+		}, &p)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if p.Revision != 1 {
+			t.Errorf("\na=%#v\ne=%#v\n", p.Revision, 1)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		update := p
+		update.Name = "r2"
+		update.BpmnXml = createTestXmlString("r2")
+		update.SvgXml = "svg2"
+		err = PutJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id, update, &p)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if p.Revision != 2 {
+			t.Errorf("\na=%#v\ne=%#v\n", p.Revision, 2)
+		}
+	})
+
+	t.Run("etag", func(t *testing.T) {
+		resp, err := Get(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		resp.Body.Close()
+		if etag := resp.Header.Get("ETag"); etag != `"2"` {
+			t.Errorf("\na=%#v\ne=%#v\n", etag, `"2"`)
+		}
+	})
+
+	t.Run("update with outdated If-Match", func(t *testing.T) {
+		update := p
+		update.Name = "conflict"
+		code, err := putWithIfMatch(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id, `"1"`, update)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != http.StatusPreconditionFailed {
+			t.Errorf("\na=%#v\ne=%#v\n", code, http.StatusPreconditionFailed)
+		}
+	})
+
+	t.Run("list revisions", func(t *testing.T) {
+		revisions := []model.ProcessRevision{}
+		err = GetJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/revisions", &revisions)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(revisions) != 2 {
+			t.Errorf("%#v", revisions)
+			return
+		}
+		if revisions[0].Revision != 2 || revisions[0].Process.Name != "r2" || revisions[0].Process.BpmnXml != "" {
+			t.Errorf("%#v", revisions[0])
+		}
+		if revisions[1].Revision != 1 || revisions[1].Process.Name != "r1" || revisions[1].UserId != userid1 {
+			t.Errorf("%#v", revisions[1])
+		}
+	})
+
+	t.Run("read revision", func(t *testing.T) {
+		revision := model.ProcessRevision{}
+		err = GetJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/revisions/1", &revision)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if revision.Process.BpmnXml != createTestXmlString("r1") || revision.Process.SvgXml != "svg1" {
+			t.Errorf("%#v", revision)
+		}
+	})
+
+	t.Run("read revision as other user", func(t *testing.T) {
+		revision := model.ProcessRevision{}
+		err = GetJSON(userjwt2, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/revisions/1", &revision)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		err = PostJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+p.Id+"/revisions/1/restore", nil, &p)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if p.Revision != 3 || p.Name != "r1" || p.BpmnXml != createTestXmlString("r1") || p.SvgXml != "svg1" {
+			t.Errorf("%#v", p)
+		}
+	})
+
+	t.Run("snapshot process without revision", func(t *testing.T) {
+		legacy := model.Process{}
+		err = PostJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes", model.Process{
+			Name:    "legacy",
+			BpmnXml: createTestXmlString("legacy"),
+		}, &legacy)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		legacy.Revision = 0
+		err = db.SetProcess(context.Background(), legacy)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		update := legacy
+		update.Name = "legacy2"
+		err = PutJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+legacy.Id, update, &update)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if update.Revision != 1 {
+			t.Errorf("\na=%#v\ne=%#v\n", update.Revision, 1)
+		}
+		revision := model.ProcessRevision{}
+		err = GetJSON(userjwt1, "http://localhost:"+conf.ServerPort+"/processes/"+legacy.Id+"/revisions/0", &revision)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if revision.Process.Name != "legacy" || revision.Process.BpmnXml != createTestXmlString("legacy") {
+			t.Errorf("%#v", revision)
+		}
+	})
+}
+
+func PutJSON(token string, url string, body interface{}, result interface{}) (err error) {
+	b := new(bytes.Buffer)
+	err = json.NewEncoder(b).Encode(body)
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequest(http.MethodPut, url, b)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(resp.Body)
+		log.Println(buf.String())
+		return errors.New(resp.Status)
+	}
+	if result != nil {
+		err = json.NewDecoder(resp.Body).Decode(result)
+	}
+	return
+}
+
+func putWithIfMatch(token string, url string, ifMatch string, body interface{}) (code int, err error) {
+	b := new(bytes.Buffer)
+	err = json.NewEncoder(b).Encode(body)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequest(http.MethodPut, url, b)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", ifMatch)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+)
+
+func TestSearch(t *testing.T) {
+	process := model.Process{
+		Name:        "Check Stock",
+		Description: "reserves <items> " + strings.Repeat("and more ", 10) + "for the stock check",
+		Metadata: model.ProcessMetadata{
+			Texts: []string{"check order", "notify", "stock-check"},
+		},
+	}
+
+	t.Run("terms", func(t *testing.T) {
+		terms := model.SearchTerms(" Stock, stock-CHECK* ")
+		expected := []string{"stock", "check"}
+		if !reflect.DeepEqual(terms, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", terms, expected)
+		}
+		if terms := model.SearchTerms(".*"); len(terms) != 0 {
+			t.Errorf("%#v", terms)
+		}
+	})
+
+	t.Run("score", func(t *testing.T) {
+		score := model.SearchScore(process, []string{"stock"})
+		expected := float64(model.SearchWeightName + model.SearchWeightDescription + model.SearchWeightTexts)
+		if score != expected {
+			t.Errorf("\na=%#v\ne=%#v\n", score, expected)
+		}
+		if score := model.SearchScore(process, []string{"stock", "unknown"}); score != 0 {
+			t.Error(score)
+		}
+		if score := model.SearchScore(process, []string{"sto"}); score != 0 {
+			t.Error(score)
+		}
+	})
+
+	t.Run("highlights", func(t *testing.T) {
+		highlights := model.HighlightSearchMatches(process, []string{"items", "check"})
+		expected := []model.SearchHighlight{
+			{Field: "name", Snippet: "<em>Check</em> Stock"},
+			{Field: "description", Snippet: "reserves &lt;<em>items</em>&gt; and more and more and more and more…"},
+			{Field: "texts", Snippet: "<em>check</em> order"},
+			{Field: "texts", Snippet: "stock-<em>check</em>"},
+		}
+		if !reflect.DeepEqual(highlights, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", highlights, expected)
+		}
+	})
+}
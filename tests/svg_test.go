@@ -0,0 +1,67 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/process-model-repository/lib/svg"
+	"github.com/beevik/etree"
+)
+
+func TestRenderSvg(t *testing.T) {
+	t.Run("render diagram", func(t *testing.T) {
+		result, err := svg.Render(createTestXmlString("svg"))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		doc := etree.NewDocument()
+		err = doc.ReadFromString(result)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if doc.Root() == nil || doc.Root().Tag != "svg" {
+			t.Error(result)
+			return
+		}
+		if viewBox := doc.Root().SelectAttrValue("viewBox", ""); viewBox != "142 250 296 100" {
+			t.Error(viewBox)
+		}
+		if count := len(doc.FindElements("//circle")); count != 2 {
+			t.Error(count, result)
+		}
+		if count := len(doc.FindElements("//rect")); count != 1 {
+			t.Error(count, result)
+		}
+		if count := len(doc.FindElements("//polyline")); count != 2 {
+			t.Error(count, result)
+		}
+		if !strings.Contains(result, ">Test</tspan>") {
+			t.Error(result)
+		}
+	})
+
+	t.Run("missing diagram interchange", func(t *testing.T) {
+		_, err := svg.Render(validationTestXmlPrefix + `<bpmn:process id="p"/>` + validationTestXmlSuffix)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+)
+
+func TestTemplate(t *testing.T) {
+	template := model.ProcessTemplate{
+		Id:   "t",
+		Name: "template",
+		BpmnXml: validationTestXmlPrefix + `<bpmn:process id="{{process_id}}" isExecutable="true">
+    <bpmn:startEvent id="start"><bpmn:timerEventDefinition><bpmn:timeDuration>{{ delay }}</bpmn:timeDuration></bpmn:timerEventDefinition></bpmn:startEvent>
+    <bpmn:task id="task" name="{{label}} x{{count}} {{enabled}}"/>
+  </bpmn:process>` + validationTestXmlSuffix,
+		SvgXml: `<svg><text>{{label}}</text></svg>`,
+		Parameters: []model.TemplateParameter{
+			{Name: "process_id", Type: model.TemplateParameterString},
+			{Name: "delay", Type: model.TemplateParameterDuration, Default: "PT5M"},
+			{Name: "label", Type: model.TemplateParameterString, Default: "task"},
+			{Name: "count", Type: model.TemplateParameterInteger},
+			{Name: "enabled", Type: model.TemplateParameterBoolean, Default: false},
+		},
+	}
+
+	t.Run("validate", func(t *testing.T) {
+		if err := template.Validate(); err != nil {
+			t.Error(err)
+		}
+		expected := []string{"process_id", "delay", "label", "count", "enabled"}
+		if placeholders := template.Placeholders(); !reflect.DeepEqual(placeholders, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", placeholders, expected)
+		}
+	})
+
+	t.Run("validate undeclared placeholder", func(t *testing.T) {
+		invalid := template
+		invalid.Parameters = template.Parameters[1:]
+		if err := invalid.Validate(); err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("validate invalid default", func(t *testing.T) {
+		invalid := template
+		invalid.Parameters = append([]model.TemplateParameter{{Name: "process_id", Type: model.TemplateParameterDuration, Default: "5 minutes"}}, template.Parameters[1:]...)
+		if err := invalid.Validate(); err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		bpmn, svg, err := template.Apply(map[string]interface{}{"process_id": "p1", "label": "a<b", "count": float64(3)})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expectedBpmn := validationTestXmlPrefix + `<bpmn:process id="p1" isExecutable="true">
+    <bpmn:startEvent id="start"><bpmn:timerEventDefinition><bpmn:timeDuration>PT5M</bpmn:timeDuration></bpmn:timerEventDefinition></bpmn:startEvent>
+    <bpmn:task id="task" name="a&lt;b x3 false"/>
+  </bpmn:process>` + validationTestXmlSuffix
+		if bpmn != expectedBpmn {
+			t.Errorf("\na=%#v\ne=%#v\n", bpmn, expectedBpmn)
+		}
+		if svg != `<svg><text>a&lt;b</text></svg>` {
+			t.Error(svg)
+		}
+	})
+
+	t.Run("apply missing value", func(t *testing.T) {
+		_, _, err := template.Apply(map[string]interface{}{"process_id": "p1"})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("apply wrong type", func(t *testing.T) {
+		_, _, err := template.Apply(map[string]interface{}{"process_id": "p1", "count": 1.5})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("apply unknown parameter", func(t *testing.T) {
+		_, _, err := template.Apply(map[string]interface{}{"process_id": "p1", "count": float64(1), "foo": "bar"})
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
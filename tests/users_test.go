@@ -131,6 +131,11 @@ func TestUserDelete(t *testing.T) {
 				return
 			}
 		}
+		err = setGroupPermission(conf, "team", processModelIds[4], "rwxa")
+		if err != nil {
+			t.Error(err)
+			return
+		}
 	})
 
 	time.Sleep(20 * time.Second)
@@ -139,35 +144,256 @@ func TestUserDelete(t *testing.T) {
 	t.Run("check user2 before delete", checkUserProcesses(conf, user2, append(append([]string{}, processModelIds[:4]...), processModelIds[10:]...)))
 
 	t.Run("delete user1", func(t *testing.T) {
-		users := &kafka.Writer{
-			Addr:        kafka.TCP(conf.KafkaUrl),
-			Topic:       conf.UsersTopic,
-			MaxAttempts: 10,
-			Logger:      log.New(os.Stdout, "[TEST-KAFKA-PRODUCER] ", 0),
-		}
-		cmd := listener.UserCommandMsg{
-			Command: "DELETE",
-			Id:      user1.GetUserId(),
-		}
-		message, err := json.Marshal(cmd)
+		err := sendUserDelete(conf, user1.GetUserId())
 		if err != nil {
 			t.Error(err)
-			return
 		}
-		err = users.WriteMessages(
-			context.Background(),
-			kafka.Message{
-				Key:   []byte(user1.GetUserId()),
-				Value: message,
-				Time:  time.Now(),
-			},
-		)
 	})
 
 	time.Sleep(20 * time.Second)
 
 	t.Run("check user1 after delete", checkUserProcesses(conf, user1, []string{}))
 	t.Run("check user2 after delete", checkUserProcesses(conf, user2, append(append(append([]string{}, processModelIds[:4]...), processModelIds[10:12]...), processModelIds[14:]...)))
+
+	t.Run("check process kept by group admin", func(t *testing.T) {
+		process := model.Process{}
+		err = GetJSON(client.InternalAdminToken, "http://localhost:"+conf.ServerPort+"/processes/"+processModelIds[4], &process)
+		if err != nil {
+			t.Error(err)
+		}
+		permissions := model.ProcessPermissions{}
+		err = GetJSON(client.InternalAdminToken, "http://localhost:"+conf.ServerPort+"/processes/"+processModelIds[4]+"/permissions", &permissions)
+		if err != nil {
+			t.Error(err)
+		}
+		if _, ok := permissions.UserPermissions[user1.GetUserId()]; ok || !permissions.GroupPermissions["team"].Administrate {
+			t.Errorf("%#v", permissions)
+		}
+		_, err = Get(client.InternalAdminToken, "http://localhost:"+conf.ServerPort+"/processes/"+processModelIds[5])
+		if err == nil {
+			t.Error("expected process of deleted user to be removed")
+		}
+	})
+}
+
+func TestUserDeletePolicies(t *testing.T) {
+	t.Run("archive", testUserDeletePolicy(func(conf *config.Config) {
+		conf.UserDeletePolicy = "archive"
+		conf.UserDeleteArchiveDays = 30
+	}, func(t *testing.T, conf config.Config, processId string) {
+		list := []model.Process{}
+		err := GetJSON(client.InternalAdminToken, "http://localhost:"+conf.ServerPort+"/v2/processes?removed=true", &list)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(list) != 1 || list[0].Id != processId || list[0].Removal == nil || list[0].Removal.Reason != model.RemovalReasonUserDelete {
+			t.Errorf("%#v", list)
+			return
+		}
+		restored := model.Process{}
+		err = PostJSON(client.InternalAdminToken, "http://localhost:"+conf.ServerPort+"/processes/"+processId+"/restore", nil, &restored)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if restored.Removal != nil {
+			t.Errorf("%#v", restored.Removal)
+		}
+		list = []model.Process{}
+		err = GetJSON(client.InternalAdminToken, "http://localhost:"+conf.ServerPort+"/v2/processes", &list)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(list) != 1 || list[0].Id != processId {
+			t.Errorf("%#v", list)
+		}
+		checkTemplatesOfDeletedUser(t, conf, "user1")
+	}))
+	t.Run("transfer", testUserDeletePolicy(func(conf *config.Config) {
+		conf.UserDeletePolicy = "transfer"
+		conf.UserDeleteFallbackUser = "fallback"
+	}, func(t *testing.T, conf config.Config, processId string) {
+		fallback, err := auth.CreateToken("test", "fallback")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		process := model.Process{}
+		err = GetJSON(fallback.Jwt(), "http://localhost:"+conf.ServerPort+"/processes/"+processId+"?p=a", &process)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if process.Owner != "fallback" {
+			t.Errorf("%#v", process.Owner)
+		}
+		records := []model.AuditRecord{}
+		err = GetJSON(fallback.Jwt(), "http://localhost:"+conf.ServerPort+"/processes/"+processId+"/audit", &records)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(records) != 1 || records[0].Action != model.AuditOwnerChange || records[0].OwnerBefore != "user1" || records[0].OwnerAfter != "fallback" {
+			t.Errorf("%#v", records)
+		}
+		checkTemplatesOfDeletedUser(t, conf, "fallback")
+	}))
+	t.Run("transfer to group", testUserDeletePolicy(func(conf *config.Config) {
+		conf.UserDeletePolicy = "transfer"
+		conf.UserDeleteFallbackGroup = "team"
+	}, func(t *testing.T, conf config.Config, processId string) {
+		process := model.Process{}
+		err := GetJSON(client.InternalAdminToken, "http://localhost:"+conf.ServerPort+"/processes/"+processId, &process)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if process.Owner != "" {
+			t.Errorf("%#v", process.Owner)
+		}
+		permissions := model.ProcessPermissions{}
+		err = GetJSON(client.InternalAdminToken, "http://localhost:"+conf.ServerPort+"/processes/"+processId+"/permissions", &permissions)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(permissions.UserPermissions) != 0 || !permissions.GroupPermissions["team"].Administrate {
+			t.Errorf("%#v", permissions)
+		}
+		checkTemplatesOfDeletedUser(t, conf, "user1")
+	}))
+}
+
+// checkTemplatesOfDeletedUser expects only the published template of user1 to be left, owned by owner
+func checkTemplatesOfDeletedUser(t *testing.T, conf config.Config, owner string) {
+	templates := []model.ProcessTemplate{}
+	err := GetJSON(client.InternalAdminToken, "http://localhost:"+conf.ServerPort+"/templates", &templates)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(templates) != 1 || templates[0].Name != "published" || templates[0].Owner != owner {
+		t.Errorf("%#v", templates)
+	}
+}
+
+// testUserDeletePolicy creates a process of user1, deletes user1 and calls check with the id of the process
+func testUserDeletePolicy(configure func(conf *config.Config), check func(t *testing.T, conf config.Config, processId string)) func(t *testing.T) {
+	return func(t *testing.T) {
+		conf, err := config.Load("../config.json")
+		if err != nil {
+			log.Fatal("ERROR: unable to load config", err)
+		}
+		conf.Debug = true
+		conf.ConnectivityTest = false
+		configure(&conf)
+
+		wg := &sync.WaitGroup{}
+		defer wg.Wait()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ctx = contextwg.WithWaitGroup(ctx, wg)
+
+		_, mongoIp, err := MongoTestServer(ctx, wg)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conf.MongoUrl = "mongodb://" + mongoIp + ":27017"
+
+		conf.KafkaUrl, err = Kafka(ctx, wg)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		time.Sleep(10 * time.Second)
+
+		_, permIp, err := PermissionsV2(ctx, wg, conf.MongoUrl, conf.KafkaUrl)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conf.PermissionsV2Url = "http://" + permIp + ":8080"
+
+		port, err := getFreePort()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conf.ServerPort = strconv.Itoa(port)
+
+		err = lib.Start(ctx, conf)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		time.Sleep(2 * time.Second)
+
+		user1, err := auth.CreateToken("test", "user1")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		processModelIds := []string{}
+		t.Run("create process model", testCreateModel(user1, conf, 1, &processModelIds))
+		if len(processModelIds) != 1 {
+			return
+		}
+
+		for _, template := range []model.ProcessTemplate{{Name: "private"}, {Name: "published", Publish: true}} {
+			err = PostJSON(user1.Jwt(), "http://localhost:"+conf.ServerPort+"/templates", template, nil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		time.Sleep(5 * time.Second)
+
+		err = sendUserDelete(conf, user1.GetUserId())
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		time.Sleep(20 * time.Second)
+
+		t.Run("check user1 after delete", checkUserProcesses(conf, user1, []string{}))
+		t.Run("check", func(t *testing.T) {
+			check(t, conf, processModelIds[0])
+		})
+	}
+}
+
+func sendUserDelete(conf config.Config, userId string) error {
+	users := &kafka.Writer{
+		Addr:        kafka.TCP(conf.KafkaUrl),
+		Topic:       conf.UsersTopic,
+		MaxAttempts: 10,
+		Logger:      log.New(os.Stdout, "[TEST-KAFKA-PRODUCER] ", 0),
+	}
+	defer users.Close()
+	message, err := json.Marshal(listener.UserCommandMsg{
+		Command: "DELETE",
+		Id:      userId,
+	})
+	if err != nil {
+		return err
+	}
+	return users.WriteMessages(
+		context.Background(),
+		kafka.Message{
+			Key:   []byte(userId),
+			Value: message,
+			Time:  time.Now(),
+		},
+	)
 }
 
 func testCreateModel(token auth.Token, conf config.Config, count int, createdIds *[]string) func(t *testing.T) {
@@ -267,3 +493,22 @@ func setPermission(conf config.Config, userId string, id string, right string) e
 	_, err, _ = c.SetPermission(client.InternalAdminToken, conf.ProcessTopic, id, old.ResourcePermissions)
 	return err
 }
+
+func setGroupPermission(conf config.Config, groupId string, id string, right string) error {
+	c := client.New(conf.PermissionsV2Url)
+	old, err, _ := c.GetResource(client.InternalAdminToken, conf.ProcessTopic, id)
+	if err != nil {
+		return err
+	}
+	if old.GroupPermissions == nil {
+		old.GroupPermissions = map[string]client.PermissionsMap{}
+	}
+	old.GroupPermissions[groupId] = client.PermissionsMap{
+		Read:         strings.Contains(right, "r"),
+		Write:        strings.Contains(right, "w"),
+		Execute:      strings.Contains(right, "x"),
+		Administrate: strings.Contains(right, "a"),
+	}
+	_, err, _ = c.SetPermission(client.InternalAdminToken, conf.ProcessTopic, id, old.ResourcePermissions)
+	return err
+}
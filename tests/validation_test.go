@@ -0,0 +1,161 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/SENERGY-Platform/process-model-repository/lib/controller"
+	"github.com/SENERGY-Platform/process-model-repository/lib/model"
+)
+
+const validationTestXmlPrefix = `<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" id="Definitions_1" targetNamespace="http://bpmn.io/schema/bpmn">`
+const validationTestXmlSuffix = `</bpmn:definitions>`
+
+func TestValidateBpmn(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		findings := model.ValidateBpmn(createTestXmlString("valid"))
+		if len(findings) != 0 {
+			t.Errorf("%#v", findings)
+		}
+		process := model.Process{Id: "valid", BpmnXml: createTestXmlString("valid")}
+		if err := process.Validate(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("invalid xml", func(t *testing.T) {
+		findings := model.ValidateBpmn("<bpmn:definitions")
+		if len(findings) != 1 || findings[0].Severity != model.SeverityError {
+			t.Errorf("%#v", findings)
+		}
+	})
+
+	t.Run("missing process", func(t *testing.T) {
+		findings := model.ValidateBpmn(validationTestXmlPrefix + validationTestXmlSuffix)
+		expected := []model.ValidationFinding{{Severity: model.SeverityError, Message: "missing process definition"}}
+		if !reflect.DeepEqual(findings, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", findings, expected)
+		}
+	})
+
+	t.Run("structural findings", func(t *testing.T) {
+		bpmn := validationTestXmlPrefix + `<bpmn:process id="p" isExecutable="true">
+    <bpmn:task id="t1"/>
+    <bpmn:task id="t1"/>
+    <bpmn:exclusiveGateway id="g1"/>
+    <bpmn:endEvent id="e1"/>
+    <bpmn:sequenceFlow id="f1" sourceRef="t1" targetRef="g1"/>
+    <bpmn:sequenceFlow id="f2" sourceRef="g1" targetRef="e1"/>
+    <bpmn:sequenceFlow id="f3" sourceRef="g1" targetRef="unknown"/>
+    <bpmn:sequenceFlow id="f4" sourceRef="e1"/>
+  </bpmn:process>` + validationTestXmlSuffix
+		findings := model.ValidateBpmn(bpmn)
+		expected := []model.ValidationFinding{
+			{ElementId: "t1", Severity: model.SeverityError, Message: "duplicate element id"},
+			{ElementId: "f3", Severity: model.SeverityError, Message: "sequence flow references unknown targetRef 'unknown'"},
+			{ElementId: "f4", Severity: model.SeverityError, Message: "sequence flow without targetRef"},
+			{ElementId: "p", Severity: model.SeverityError, Message: "missing start event"},
+			{ElementId: "t1", Severity: model.SeverityWarning, Message: "element is not reachable from a start event"},
+			{ElementId: "g1", Severity: model.SeverityWarning, Message: "element is not reachable from a start event"},
+			{ElementId: "e1", Severity: model.SeverityWarning, Message: "element is not reachable from a start event"},
+		}
+		if !reflect.DeepEqual(findings, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", findings, expected)
+		}
+
+		process := model.Process{Id: "p", BpmnXml: bpmn}
+		err := process.Validate()
+		var validationErr *model.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("%#v", err)
+			return
+		}
+		if !reflect.DeepEqual(validationErr.Findings, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", validationErr.Findings, expected)
+		}
+	})
+
+	t.Run("gateway warnings do not invalidate", func(t *testing.T) {
+		bpmn := validationTestXmlPrefix + `<bpmn:process id="p" isExecutable="true">
+    <bpmn:startEvent id="s1"/>
+    <bpmn:parallelGateway id="g1"/>
+    <bpmn:endEvent id="e1"/>
+    <bpmn:sequenceFlow id="f1" sourceRef="s1" targetRef="g1"/>
+    <bpmn:sequenceFlow id="f2" sourceRef="g1" targetRef="e1"/>
+  </bpmn:process>` + validationTestXmlSuffix
+		findings := model.ValidateBpmn(bpmn)
+		expected := []model.ValidationFinding{
+			{ElementId: "g1", Severity: model.SeverityWarning, Message: "gateway with a single outgoing sequence flow neither splits nor joins the flow"},
+		}
+		if !reflect.DeepEqual(findings, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", findings, expected)
+		}
+		process := model.Process{Id: "p", BpmnXml: bpmn}
+		if err := process.Validate(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("sub process and boundary events", func(t *testing.T) {
+		bpmn := validationTestXmlPrefix + `<bpmn:process id="p" isExecutable="true">
+    <bpmn:startEvent id="s1"/>
+    <bpmn:subProcess id="sub">
+      <bpmn:startEvent id="s2"/>
+      <bpmn:endEvent id="e2"/>
+      <bpmn:sequenceFlow id="f3" sourceRef="s2" targetRef="e2"/>
+    </bpmn:subProcess>
+    <bpmn:boundaryEvent id="b1" attachedToRef="sub"/>
+    <bpmn:endEvent id="e1"/>
+    <bpmn:endEvent id="e3"/>
+    <bpmn:sequenceFlow id="f1" sourceRef="s1" targetRef="sub"/>
+    <bpmn:sequenceFlow id="f2" sourceRef="sub" targetRef="e1"/>
+    <bpmn:sequenceFlow id="f4" sourceRef="b1" targetRef="e3"/>
+  </bpmn:process>` + validationTestXmlSuffix
+		findings := model.ValidateBpmn(bpmn)
+		if len(findings) != 0 {
+			t.Errorf("%#v", findings)
+		}
+	})
+}
+
+func TestDryRunValidation(t *testing.T) {
+	ctrl := &controller.Controller{}
+	t.Run("valid", func(t *testing.T) {
+		result, err, _ := ctrl.ValidateProcess(model.Process{BpmnXml: createTestXmlString("dry")})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := model.ValidationResult{Valid: true, Name: "dry", Findings: []model.ValidationFinding{}}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("\na=%#v\ne=%#v\n", result, expected)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		result, err, _ := ctrl.ValidateProcess(model.Process{BpmnXml: validationTestXmlPrefix + validationTestXmlSuffix})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Valid || len(result.Findings) != 2 {
+			t.Errorf("%#v", result)
+		}
+	})
+}